@@ -0,0 +1,65 @@
+package dancinglinks
+
+// ApproxCover returns some answer quickly, even for hard instances.
+// It first searches for an exact cover, visiting at most budget search
+// nodes.  Failing that, it falls back to a greedy heuristic, which
+// repeatedly covers the most constrained remaining item using the
+// largest option available, skipping items it can no longer cover.
+// It returns the selected options together with the items left
+// uncovered, which are none for an exact cover.
+func (dl *DLX) ApproxCover(budget int) (cover []int, uncovered []int) {
+	defer dl.SetNodeLimit(dl.nodeLimit)
+	dl.SetNodeLimit(budget)
+
+	if cover := dl.AnyCover(); cover != nil {
+		return cover, []int{}
+	}
+	return dl.greedyCover()
+}
+
+func (dl *DLX) greedyCover() ([]int, []int) {
+	cover := []int{}
+	uncovered := []int{}
+
+	// Moves made, so that they can be undone afterwards.
+	type greedyMove struct {
+		item   int
+		option int
+	}
+	moves := []greedyMove{}
+
+	for index := dl.nextItem(); index != root; index = dl.nextItem() {
+		item := &dl.items[index]
+		if item.choices == 0 {
+			// Nothing covers the item; give up on it.
+			dl.cover(index)
+			uncovered = append(uncovered, index)
+			moves = append(moves, greedyMove{item: index, option: -1})
+			continue
+		}
+
+		best, bestSize := -1, 0
+		for e := int(dl.entries[item.head].down); e != item.head; e = int(dl.entries[e].down) {
+			option := int(dl.entries[e].option)
+			if size := dl.primarySize(option); size > bestSize {
+				best, bestSize = option, size
+			}
+		}
+
+		dl.chooseOption(best)
+		cover = append(cover, best)
+		moves = append(moves, greedyMove{item: index, option: best})
+	}
+
+	// Undo the moves in reverse order.
+	for i := len(moves) - 1; i >= 0; i-- {
+		m := moves[i]
+		if m.option == -1 {
+			dl.uncover(m.item)
+		} else {
+			dl.unchooseOption(m.option)
+		}
+	}
+
+	return cover, uncovered
+}
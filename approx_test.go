@@ -0,0 +1,26 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApproxCover(t *testing.T) {
+	cover, uncovered := classic.toDLX().ApproxCover(100)
+	if !reflect.DeepEqual(cover, []int{3, 4, 0}) || len(uncovered) != 0 {
+		t.Errorf("should find exact cover [3 4 0], got %v leaving %v", cover, uncovered)
+	}
+
+	// Greedily selecting option 0 leaves item 2 uncovered.
+	dl := impossible.toDLX()
+	cover, uncovered = dl.ApproxCover(100)
+	if !reflect.DeepEqual(cover, []int{0}) || !reflect.DeepEqual(uncovered, []int{2}) {
+		t.Errorf("should select [0] leaving [2], got %v leaving %v", cover, uncovered)
+	}
+
+	// The structure is restored afterwards.
+	if mat := dl.ToMatrix(); !reflect.DeepEqual(mat, impossible.matrix) {
+		t.Errorf("matrix should be restored, got\n%s", sprintMatrix(mat))
+	}
+	testExample(t, dl.AllSolutions(), impossible.solution)
+}
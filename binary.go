@@ -0,0 +1,301 @@
+package dancinglinks
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Marks the start of the binary form of a problem, followed by the
+// version of the format.
+const binaryMagic = "DLX\x00"
+const binaryVersion = 1
+
+// WriteTo writes the problem in a compact binary form that ReadDLX
+// decodes without setting it up anew: its items and entries as stored,
+// labels, and forced and disabled options.  Like MarshalJSON, it leaves
+// out settings, costs, and payloads.  It must not be called during a
+// search.
+func (dl *DLX) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var n int64
+	buf := make([]byte, 0, 4*5)
+	put := func(values ...int32) {
+		buf = buf[:0]
+		for _, v := range values {
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(v))
+		}
+		m, _ := bw.Write(buf)
+		n += int64(m)
+	}
+	putList := func(list []int) {
+		put(int32(len(list)))
+		for _, v := range list {
+			put(int32(v))
+		}
+	}
+	putLabels := func(labels []string) {
+		if labels == nil {
+			put(-1)
+			return
+		}
+		put(int32(len(labels)))
+		for _, label := range labels {
+			put(int32(len(label)))
+			m, _ := bw.WriteString(label)
+			n += int64(m)
+		}
+	}
+
+	m, _ := bw.WriteString(binaryMagic)
+	n += int64(m)
+	put(binaryVersion, int32(len(dl.items)), int32(len(dl.options)), int32(len(dl.entries)))
+	for _, item := range dl.items {
+		secondary := int32(0)
+		if item.secondary {
+			secondary = 1
+		}
+		put(int32(item.head), secondary)
+	}
+	for _, first := range dl.options {
+		put(first)
+	}
+	for i := range dl.entries {
+		entry := &dl.entries[i]
+		put(entry.item, entry.option, entry.left, entry.right, entry.color)
+	}
+	putList(dl.selected)
+	putList(dl.disabled)
+	putLabels(dl.itemLabels)
+	putLabels(dl.optionLabels)
+
+	return n, bw.Flush()
+}
+
+// ReadDLX reads a problem written by WriteTo, with default settings.
+func ReadDLX(r io.Reader) (*DLX, error) {
+	br := bufio.NewReader(r)
+	var err error
+	buf := make([]byte, 4*5)
+	get := func(values ...*int32) {
+		if err != nil {
+			return
+		}
+		if _, err = io.ReadFull(br, buf[:4*len(values)]); err != nil {
+			return
+		}
+		for i, v := range values {
+			*v = int32(binary.LittleEndian.Uint32(buf[4*i:]))
+		}
+	}
+	// Reads a count, which must be between 0 and limit.
+	getCount := func(what string, limit int) int {
+		var count int32
+		get(&count)
+		if err == nil && (count < 0 || int(count) > limit) {
+			err = fmt.Errorf("dancinglinks: %s %d out of range [0, %d]", what, count, limit)
+		}
+		return int(count)
+	}
+	getList := func(what string, limit int) []int {
+		list := make([]int, getCount(what+" count", limit))
+		for i := range list {
+			var v int32
+			get(&v)
+			if err == nil && (v < 0 || int(v) >= limit) {
+				err = fmt.Errorf("dancinglinks: %s %d out of range [0, %d)", what, v, limit)
+			}
+			list[i] = int(v)
+		}
+		return list
+	}
+	getLabels := func(count int) []string {
+		var n int32
+		if get(&n); err != nil || n == -1 {
+			return nil
+		}
+		if int(n) != count {
+			err = fmt.Errorf("dancinglinks: %d labels for %d elements", n, count)
+			return nil
+		}
+		labels := make([]string, count)
+		for i := range labels {
+			// Copy the label rather than trusting its length to
+			// allocate a buffer up front.
+			b := &strings.Builder{}
+			length := getCount("label length", math.MaxInt32)
+			if err == nil {
+				if _, err = io.CopyN(b, br, int64(length)); err == io.EOF {
+					err = io.ErrUnexpectedEOF
+				}
+			}
+			labels[i] = b.String()
+		}
+		return labels
+	}
+
+	magic := make([]byte, len(binaryMagic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return nil, err
+	}
+	var version, itemCount, optionCount, entryCount int32
+	get(&version, &itemCount, &optionCount, &entryCount)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(magic, []byte(binaryMagic)) || version != binaryVersion {
+		return nil, errors.New("dancinglinks: not a problem written by WriteTo")
+	}
+	if itemCount < 0 || optionCount < 0 || entryCount < itemCount {
+		return nil, fmt.Errorf("dancinglinks: %d items, %d options, and %d entries are inconsistent", itemCount, optionCount, entryCount)
+	}
+
+	// Grow the slices as records are read, rather than trusting the
+	// counts to allocate them up front.
+	const chunk = 1 << 16
+	dl := &DLX{
+		entries:  make([]entryNode, 0, min(entryCount, chunk)),
+		options:  make([]int32, 0, min(optionCount, chunk)),
+		itemHead: itemNode{left: root, right: root, head: -1},
+		items:    make([]itemNode, 0, min(itemCount, chunk)),
+		selected: []int{},
+	}
+	for i := 0; i < int(itemCount) && err == nil; i++ {
+		var head, secondary int32
+		get(&head, &secondary)
+		if err == nil && secondary != 0 && secondary != 1 {
+			err = fmt.Errorf("dancinglinks: item %d has secondary flag %d", i, secondary)
+		}
+
+		// Secondary items stay out of the list of items to cover,
+		// pointing only to themselves, while Reset links the others.
+		dl.items = append(dl.items, itemNode{
+			head:      int(head),
+			secondary: secondary == 1,
+			left:      i,
+			right:     i,
+		})
+	}
+	for i := 0; i < int(optionCount) && err == nil; i++ {
+		var first int32
+		get(&first)
+		dl.options = append(dl.options, first)
+	}
+	for i := 0; i < int(entryCount) && err == nil; i++ {
+		var entry entryNode
+		get(&entry.item, &entry.option, &entry.left, &entry.right, &entry.color)
+		dl.entries = append(dl.entries, entry)
+	}
+	dl.hidden = make([]int, len(dl.options))
+	if err != nil {
+		return nil, err
+	}
+	if err := dl.checkLinks(); err != nil {
+		return nil, err
+	}
+
+	selected := getList("forced option", int(optionCount))
+	disabled := getList("disabled option", int(optionCount))
+	dl.itemLabels = getLabels(int(itemCount))
+	dl.optionLabels = getLabels(int(optionCount))
+	if err != nil {
+		return nil, err
+	}
+
+	// Link the columns and the list of items, then disable and force
+	// the options as before.
+	dl.Reset()
+	if err := dl.disableAndForce(disabled, selected); err != nil {
+		return nil, err
+	}
+	return dl, nil
+}
+
+// Checks that the item headers and the rows of options read by ReadDLX
+// are consistent, so that linking the columns is safe.
+func (dl *DLX) checkLinks() error {
+	corrupt := errors.New("dancinglinks: corrupt entries")
+	for i, item := range dl.items {
+		if item.head < 0 || item.head >= len(dl.entries) {
+			return corrupt
+		}
+		if header := &dl.entries[item.head]; header.item != int32(i) || header.option != -1 {
+			return corrupt
+		}
+	}
+
+	headers := 0
+	for i := range dl.entries {
+		entry := &dl.entries[i]
+		if entry.item < 0 || int(entry.item) >= len(dl.items) || entry.option < -1 || int(entry.option) >= len(dl.options) {
+			return corrupt
+		}
+		if entry.option == -1 {
+			headers++
+		}
+		if entry.color != 0 && (entry.option == -1 || !dl.items[entry.item].secondary) {
+			return corrupt
+		}
+	}
+	if headers != len(dl.items) {
+		return corrupt
+	}
+
+	// Each row must be a cycle of the option's entries covering
+	// distinct items, and together the rows must take up all the other
+	// entries.
+	visited := 0
+	covers := make([]int, len(dl.items))
+	for i := range covers {
+		covers[i] = -1
+	}
+	for option, first := range dl.options {
+		if first == -1 {
+			continue
+		}
+		if first < 0 || int(first) >= len(dl.entries) {
+			return corrupt
+		}
+		for e := first; ; {
+			entry := &dl.entries[e]
+			if entry.option != int32(option) || entry.right < 0 || int(entry.right) >= len(dl.entries) || dl.entries[entry.right].left != e {
+				return corrupt
+			}
+			if visited++; visited > len(dl.entries)-headers || covers[entry.item] == option {
+				return corrupt
+			}
+			covers[entry.item] = option
+			if e = entry.right; e == first {
+				break
+			}
+		}
+	}
+	if visited != len(dl.entries)-headers {
+		return corrupt
+	}
+	return nil
+}
+
+// MarshalBinary encodes the problem as WriteTo does, so that it can be
+// stored with encoding/gob.
+func (dl *DLX) MarshalBinary() ([]byte, error) {
+	b := &bytes.Buffer{}
+	_, err := dl.WriteTo(b)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary replaces the problem with one encoded by
+// MarshalBinary.
+func (dl *DLX) UnmarshalBinary(data []byte) error {
+	decoded, err := ReadDLX(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	*dl = *decoded
+	return nil
+}
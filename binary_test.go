@@ -0,0 +1,122 @@
+package dancinglinks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"reflect"
+	"testing"
+)
+
+func TestBinary(t *testing.T) {
+	labeled, err := NewLabeled(sushiItems, sushiOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	labeled.DisableOption(5)
+	labeled.ForceOptions(0)
+	labeled.DisableOption(0)
+
+	extended := classic.toDLX()
+	extended.AddItem(0, 2)
+	extended.AddOption([]int{7})
+
+	for _, dl := range []*DLX{
+		classicDuplicates.toDLX(),
+		queens(4),
+		New(3, [][]int{{0}, {}, {1, 2}}, WithSecondaryItems(1)),
+		extended,
+		labeled,
+	} {
+		b := &bytes.Buffer{}
+		n, err := dl.WriteTo(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != int64(b.Len()) {
+			t.Errorf("should count %d bytes written, counted %d", b.Len(), n)
+		}
+		data := b.Bytes()
+
+		decoded, err := ReadDLX(b)
+		if err != nil {
+			t.Fatalf("should decode %v, got %v", dl, err)
+		}
+		if !decoded.Equal(dl) {
+			t.Errorf("should decode %v, got %v", dl, decoded)
+		}
+		if got, want := decoded.String(), dl.String(); got != want {
+			t.Errorf("should decode to\n%s\ngot\n%s", want, got)
+		}
+		if got, want := decoded.AllSolutions(), dl.AllSolutions(); !reflect.DeepEqual(got, want) {
+			t.Errorf("should solve like the original, found %v instead of %v", got, want)
+		}
+
+		// Truncated or altered data is rejected rather than linked.
+		for cut := 0; cut < len(data); cut++ {
+			if _, err := ReadDLX(bytes.NewReader(data[:cut])); err == nil {
+				t.Errorf("should reject data cut at %d", cut)
+			}
+		}
+		for i := len(binaryMagic) + 16; i < len(data); i += 4 {
+			altered := append([]byte{}, data...)
+			altered[i] ^= 0x40
+			if decoded, err := ReadDLX(bytes.NewReader(altered)); err == nil {
+				// Changing colors, labels, or flags may still leave a
+				// valid problem, which must then be searchable.
+				decoded.CountSolutions()
+			}
+		}
+	}
+
+	// Decoded secondary items must stay out of the list of items, so
+	// that searching again finds the same solutions.
+	colored := NewColored(2, 1, [][]ColoredItem{{{0, 0}, {2, 0}}, {{1, 0}}, {{1, 0}, {2, 0}}, {{0, 0}}})
+	data, err := colored.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded DLX
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	decoded.SetEngine(EngineLinked)
+	want := colored.AllSolutions()
+	for i := 0; i < 2; i++ {
+		if got := decoded.AllSolutions(); !reflect.DeepEqual(got, want) {
+			t.Errorf("search %d should find %v, found %v", i, want, got)
+		}
+	}
+
+	// Rows covering an item twice cannot be linked.
+	twice := New(2, [][]int{{0, 1}})
+	twice.entries[twice.options[0]+1].item = 0
+	data, err = twice.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadDLX(bytes.NewReader(data)); err == nil {
+		t.Errorf("should reject an option covering an item twice")
+	}
+
+	// Counts in a corrupt header do not allocate before the records
+	// they promise are read.
+	header := []byte(binaryMagic)
+	for _, v := range []uint32{binaryVersion, 1 << 30, 1 << 30, 1<<31 - 1} {
+		header = binary.LittleEndian.AppendUint32(header, v)
+	}
+	if _, err := ReadDLX(bytes.NewReader(header)); err == nil {
+		t.Errorf("should reject a header promising more than follows")
+	}
+
+	b := &bytes.Buffer{}
+	if err := gob.NewEncoder(b).Encode(labeled); err != nil {
+		t.Fatal(err)
+	}
+	if err := gob.NewDecoder(b).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(labeled) {
+		t.Errorf("should decode %v from gob, got %v", labeled, &decoded)
+	}
+}
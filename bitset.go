@@ -0,0 +1,101 @@
+package dancinglinks
+
+// An exact cover problem with at most 64 items, whose options are
+// represented by bitmasks of the items they cover.
+type bitset struct {
+	// The primary items left to cover, in the order of the item list,
+	// and for each, the options covering it, in column order, as
+	// indices into masks.
+	items   []int
+	columns [][]int
+
+	// The items covered by each available option, and the option's
+	// original index.
+	masks   []uint64
+	options []int
+}
+
+// Sets up the bitmask representation of the items left to cover and
+// the options still available, or returns nil if the problem has too
+// many items, or colors.
+func (dl *DLX) newBitset() *bitset {
+	if len(dl.items) > 64 {
+		return nil
+	}
+
+	b := &bitset{}
+	positions := make([]int, len(dl.options))
+	for i := range positions {
+		positions[i] = -1
+	}
+	for item := dl.itemHead.right; item != root; item = dl.items[item].right {
+		column := []int{}
+		head := dl.items[item].head
+		for e := int(dl.entries[head].down); e != head; e = int(dl.entries[e].down) {
+			option := int(dl.entries[e].option)
+			position := positions[option]
+			if position == -1 {
+				mask := uint64(0)
+				for entry := range dl.row(option) {
+					if dl.entries[entry].color != 0 {
+						return nil
+					}
+					mask |= 1 << dl.entries[entry].item
+				}
+
+				position = len(b.masks)
+				positions[option] = position
+				b.masks = append(b.masks, mask)
+				b.options = append(b.options, option)
+			}
+			column = append(column, position)
+		}
+
+		b.items = append(b.items, item)
+		b.columns = append(b.columns, column)
+	}
+	return b
+}
+
+// Yields every cover extending the given partial one, whose options
+// cover the given items, reporting whether to go on.  The yielded
+// slice is reused.
+func (b *bitset) search(dl *DLX, covered uint64, cover []int, yield func([]int) bool) bool {
+	// Branch on the first item with the fewest remaining choices.
+	best, fewest := -1, 0
+	for i, item := range b.items {
+		if covered&(1<<item) != 0 {
+			continue
+		}
+
+		choices := 0
+		for _, option := range b.columns[i] {
+			if b.masks[option]&covered == 0 {
+				choices++
+			}
+		}
+		if best == -1 || choices < fewest {
+			best, fewest = i, choices
+			if choices == 0 {
+				break
+			}
+		}
+	}
+
+	if best == -1 {
+		return yield(cover)
+	}
+
+	for _, option := range b.columns[best] {
+		if b.masks[option]&covered != 0 {
+			continue
+		}
+		if !dl.visit() {
+			return false
+		}
+		if !b.search(dl, covered|b.masks[option], append(cover, b.options[option]), yield) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Options placing n queens on an n-by-n board: rows and columns are
+// primary items, and diagonals secondary ones.
+func queens(n int) *DLX {
+	options := [][]ColoredItem{}
+	for row := 0; row < n; row++ {
+		for column := 0; column < n; column++ {
+			options = append(options, []ColoredItem{
+				{row, 0},
+				{n + column, 0},
+				{2*n + row + column, 0},
+				{4*n - 1 + (n - 1 + row - column), 0},
+			})
+		}
+	}
+	return NewColored(2*n, 4*n-2, options)
+}
+
+func TestBitset(t *testing.T) {
+	for _, dl := range []*DLX{
+		classic.toDLX(),
+		classicDuplicates.toDLX(),
+		impossible.toDLX(),
+		trivial.toDLX(),
+		queens(6),
+	} {
+		if dl.newBitset() == nil {
+			t.Errorf("should fit in bitmasks")
+		}
+
+		// Solutions come from the linked search, covers from the
+		// bitmasks.
+		want := [][]int{}
+		for _, solution := range dl.AllSolutions() {
+			want = append(want, coverOf(solution))
+		}
+		if got := dl.AllCovers(); !reflect.DeepEqual(got, want) {
+			t.Errorf("should find %v, found %v", want, got)
+		}
+	}
+
+	if count := queens(8).CountSolutions(); count.Int64() != 92 {
+		t.Errorf("should count 92 solutions to 8 queens, counted %v", count)
+	}
+
+	// Colors and more than 64 items are left to the linked search.
+	colored := NewColored(1, 1, [][]ColoredItem{{{0, 0}, {1, 1}}})
+	if colored.newBitset() != nil {
+		t.Errorf("should not handle colors")
+	}
+	itemCount, options := latinSquare(5)
+	if New(itemCount, options).newBitset() != nil {
+		t.Errorf("should not handle %d items", itemCount)
+	}
+}
+
+func BenchmarkBitsetQueens(b *testing.B) {
+	dl := queens(8)
+	for i := 0; i < b.N; i++ {
+		dl.CountSolutions()
+	}
+}
@@ -0,0 +1,141 @@
+package dancinglinks
+
+// Problems with at least this many items sort the items left to cover
+// into buckets by their remaining choices, instead of scanning them
+// all for the next item to cover at every node of the search.
+var itemBucketThreshold = 512
+
+// The primary items left to cover, in doubly linked lists by their
+// number of remaining choices.  The lists are kept up to date as items
+// are covered and their choices change, in constant time per change,
+// and rebuilt from scratch whenever the structure is modified
+// otherwise.
+type itemBuckets struct {
+	nodes []itemNode
+
+	// Links of the lists.  Nodes 0 through n-1 are the items, and node
+	// n+c is the anchor of the list of items with c choices.  An item
+	// not in any list, being covered or secondary, links to itself.
+	next []int
+	prev []int
+
+	// The number of items in the lists, and a lower bound on the
+	// fewest choices among them.
+	size int
+	low  int
+
+	// Whether ties go to the item touched last, rather than to the
+	// lowest index, and the item order otherwise breaking ties.
+	lastTouched bool
+	rank        []int
+}
+
+// The buckets of the items left to cover, or nil if the problem is
+// too small for them to pay off, or the tie-breaking policy needs to
+// see all the tied items.
+func (dl *DLX) newItemBuckets() *itemBuckets {
+	if len(dl.items) < itemBucketThreshold ||
+		(dl.tieBreak != TieLowestIndex && dl.tieBreak != TieLastTouched) {
+		return nil
+	}
+
+	// No item has more choices than it has entries.
+	sizes := make([]int, len(dl.items))
+	most := 0
+	for _, entry := range dl.entries {
+		if entry.option == -1 {
+			continue
+		}
+		if sizes[entry.item]++; sizes[entry.item] > most {
+			most = sizes[entry.item]
+		}
+	}
+
+	n := len(dl.items) + most + 1
+	b := &itemBuckets{
+		nodes:       dl.items,
+		next:        make([]int, n),
+		prev:        make([]int, n),
+		lastTouched: dl.tieBreak == TieLastTouched,
+		rank:        dl.itemRank,
+	}
+	for i := range b.next {
+		b.next[i], b.prev[i] = i, i
+	}
+	for item := dl.itemHead.right; item != root; item = dl.items[item].right {
+		b.insert(item)
+	}
+	return b
+}
+
+func (b *itemBuckets) insert(item int) {
+	choices := b.nodes[item].choices
+	anchor := len(b.nodes) + choices
+	b.next[item] = b.next[anchor]
+	b.prev[item] = anchor
+	b.prev[b.next[anchor]] = item
+	b.next[anchor] = item
+	if b.size == 0 || choices < b.low {
+		b.low = choices
+	}
+	b.size++
+}
+
+func (b *itemBuckets) delete(item int) {
+	b.next[b.prev[item]] = b.next[item]
+	b.prev[b.next[item]] = b.prev[item]
+	b.next[item], b.prev[item] = item, item
+	b.size--
+}
+
+// The item to cover next, or root if there is none.
+func (b *itemBuckets) first() int {
+	if b.size == 0 {
+		return root
+	}
+
+	anchor := len(b.nodes) + b.low
+	for b.next[anchor] == anchor {
+		b.low++
+		anchor++
+	}
+
+	best := b.next[anchor]
+	for item := b.next[best]; item != anchor; item = b.next[item] {
+		if b.before(item, best) {
+			best = item
+		}
+	}
+	return best
+}
+
+// Whether one tied item goes before another.
+func (b *itemBuckets) before(item, other int) bool {
+	if b.lastTouched {
+		x, y := b.nodes[item].touched, b.nodes[other].touched
+		if x != y {
+			return x > y
+		}
+	}
+	return itemRanked(b.rank, item, other)
+}
+
+// Moves an item to the list matching its changed choices.
+func (b *itemBuckets) update(item int) {
+	if b.next[item] != item {
+		b.delete(item)
+		b.insert(item)
+	}
+}
+
+func (b *itemBuckets) remove(item int) {
+	if b.next[item] != item {
+		b.delete(item)
+	}
+}
+
+func (b *itemBuckets) restore(item int) {
+	if !b.nodes[item].secondary {
+		b.insert(item)
+	}
+}
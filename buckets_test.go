@@ -0,0 +1,48 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestItemBuckets(t *testing.T) {
+	defer func(threshold int) { itemBucketThreshold = threshold }(itemBucketThreshold)
+
+	latin := func() *DLX {
+		itemCount, options := latinSquare(3)
+		return New(itemCount, options)
+	}
+
+	for _, policy := range []TieBreak{TieLowestIndex, TieLastTouched} {
+		for _, setup := range []func() *DLX{
+			classic.toDLX,
+			classicDuplicates.toDLX,
+			impossible.toDLX,
+			trivial.toDLX,
+			latin,
+		} {
+			itemBucketThreshold = 512
+			dl := setup()
+			dl.SetTieBreak(policy)
+			want := dl.AllSolutions()
+
+			itemBucketThreshold = 0
+			dl = setup()
+			dl.SetTieBreak(policy)
+			if got := dl.AllSolutions(); !reflect.DeepEqual(got, want) {
+				t.Errorf("policy %d: buckets should find %v, found %v", policy, want, got)
+			}
+		}
+	}
+
+	// Buckets set up while an option is forced make room for the
+	// choices regained once it is no longer.
+	itemBucketThreshold = 0
+	dl := latin()
+	dl.ForceOptions(0)
+	dl.AllSolutions()
+	dl.UnforceOptions()
+	if count := len(dl.AllSolutions()); count != 12 {
+		t.Errorf("should find 12 latin squares, found %d", count)
+	}
+}
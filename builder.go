@@ -0,0 +1,107 @@
+package dancinglinks
+
+import (
+	"fmt"
+)
+
+// A Builder sets up a labeled exact cover problem piece by piece,
+// keeping track of indices by name.  Mistakes such as unknown or
+// duplicate names are reported by Build.
+type Builder struct {
+	items    []string
+	options  []LabeledOption
+	required []string
+
+	// Indices of the items and options added so far, by name.
+	itemIndices   map[string]int
+	optionIndices map[string]int
+
+	// The first mistake made, if any.
+	err error
+}
+
+// NewBuilder returns an empty Builder.
+func NewBuilder() *Builder {
+	return &Builder{
+		itemIndices:   map[string]int{},
+		optionIndices: map[string]int{},
+	}
+}
+
+// AddItem adds an item with the given name, which must be new.
+func (b *Builder) AddItem(name string) *Builder {
+	if _, ok := b.itemIndices[name]; ok {
+		b.fail(fmt.Errorf("dancinglinks: duplicate item %q", name))
+		return b
+	}
+	b.itemIndices[name] = len(b.items)
+	b.items = append(b.items, name)
+	return b
+}
+
+// AddOption adds an option with the given name, which must be new,
+// covering the given previously added items.
+func (b *Builder) AddOption(name string, items ...string) *Builder {
+	if _, ok := b.optionIndices[name]; ok {
+		b.fail(fmt.Errorf("dancinglinks: duplicate option %q", name))
+		return b
+	}
+
+	seen := map[string]bool{}
+	for _, item := range items {
+		if _, ok := b.itemIndices[item]; !ok {
+			b.fail(fmt.Errorf("dancinglinks: option %q covers unknown item %q", name, item))
+			return b
+		}
+		if seen[item] {
+			b.fail(fmt.Errorf("dancinglinks: option %q covers item %q more than once", name, item))
+			return b
+		}
+		seen[item] = true
+	}
+
+	b.optionIndices[name] = len(b.options)
+	b.options = append(b.options, LabeledOption{name, append([]string{}, items...)})
+	return b
+}
+
+// Require marks an option, which may be added later, as forced.
+func (b *Builder) Require(option string) *Builder {
+	b.required = append(b.required, option)
+	return b
+}
+
+// Build sets up the problem, with the required options forced, or
+// returns the first mistake made while describing it.  Required
+// options that conflict with each other are reported as a
+// *ConflictError.
+func (b *Builder) Build() (*DLX, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	dl, err := NewLabeled(b.items, b.options)
+	if err != nil {
+		return nil, err
+	}
+
+	required := make([]int, len(b.required))
+	for i, name := range b.required {
+		index, ok := b.optionIndices[name]
+		if !ok {
+			return nil, fmt.Errorf("dancinglinks: required option %q does not exist", name)
+		}
+		required[i] = index
+	}
+	if err := dl.ForceOptionsChecked(required...); err != nil {
+		return nil, err
+	}
+	return dl, nil
+}
+
+// Records a mistake, unless one was made already.
+func (b *Builder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
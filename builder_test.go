@@ -0,0 +1,67 @@
+package dancinglinks
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func sushiBuilder() *Builder {
+	b := NewBuilder()
+	for _, item := range sushiItems {
+		b.AddItem(item)
+	}
+	for _, option := range sushiOptions {
+		b.AddOption(option.Name, option.Items...)
+	}
+	return b
+}
+
+func TestBuilder(t *testing.T) {
+	dl, err := sushiBuilder().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testExample(t, dl.AllSolutions()[:1], [][]Step{
+		[]Step{
+			Step{0, 3, []int{1, 3}, 0, 7, "hibachi", "local specials"},
+			Step{1, 4, []int{4}, 1, 4, "albacore", "catch of the day"},
+			Step{2, 0, []int{0}, 2, 2, "salmon", "chef's choice"},
+		},
+	})
+
+	dl, err = sushiBuilder().Require("catch of the day").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	covers := [][]string{}
+	dl.GenerateLabeledCovers(func(cover []string) bool {
+		covers = append(covers, cover)
+		return true
+	})
+	expected := [][]string{{"local specials", "chef's choice"}}
+	if !reflect.DeepEqual(covers, expected) {
+		t.Errorf("should be %q, got %q", expected, covers)
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	for name, b := range map[string]*Builder{
+		"duplicate item":     sushiBuilder().AddItem("egg"),
+		"duplicate option":   sushiBuilder().AddOption("chef's choice", "egg"),
+		"unknown item":       sushiBuilder().AddOption("dessert", "mochi"),
+		"repeated item":      sushiBuilder().AddOption("double egg", "egg", "egg"),
+		"unknown required":   sushiBuilder().Require("dessert"),
+		"conflicting forced": sushiBuilder().Require("chef's choice").Require("party platter"),
+	} {
+		if dl, err := b.Build(); err == nil {
+			t.Errorf("%s: expected an error, got %v", name, dl)
+		}
+	}
+
+	var conflict *ConflictError
+	_, err := sushiBuilder().Require("chef's choice").Require("party platter").Build()
+	if !errors.As(err, &conflict) || conflict.Option != 5 || conflict.With != 0 {
+		t.Errorf("expected a conflict between options 5 and 0, got %v", err)
+	}
+}
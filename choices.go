@@ -0,0 +1,10 @@
+package dancinglinks
+
+// SetOmitChoices sets whether the steps of yielded solutions leave out
+// their Choices, which are then nil.  Copying each step's choices
+// dominates the cost of yielding a solution, so consumers that only
+// need the selected options, like those of GenerateCovers, can spare
+// the solver that work.
+func (dl *DLX) SetOmitChoices(omit bool) {
+	dl.omitChoices = omit
+}
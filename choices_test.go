@@ -0,0 +1,37 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOmitChoices(t *testing.T) {
+	dl := New(classicDuplicates.itemCount, classicDuplicates.options, WithoutChoices())
+	solutions := dl.AllSolutions()
+	for _, solution := range solutions {
+		for _, step := range solution {
+			if step.Choices != nil {
+				t.Errorf("step %+v should leave out its choices", step)
+			}
+		}
+	}
+	if covers, expected := coverSet(solutions), coverSet(classicDuplicates.solution); !reflect.DeepEqual(covers, expected) {
+		t.Errorf("should be %v, got %v", expected, covers)
+	}
+
+	dl.SetOmitChoices(false)
+	for _, step := range dl.AllSolutions()[0] {
+		if len(step.Choices) == 0 {
+			t.Errorf("step %+v should list its choices", step)
+		}
+	}
+}
+
+func BenchmarkOmitChoices(b *testing.B) {
+	itemCount, options := latinSquare(6)
+	dl := New(itemCount, options, WithoutChoices())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dl.AllSolutionsN(100)
+	}
+}
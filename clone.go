@@ -0,0 +1,43 @@
+package dancinglinks
+
+import (
+	"math/rand"
+)
+
+// Clone returns an independent deep copy of the DLX, including forced
+// options and all settings, so that the copies can be explored
+// separately, e.g. on different goroutines.  A randomized clone gets
+// its own source of randomness, seeded from the original's.  Cloning
+// a DLX in the middle of a search is not supported.
+func (dl *DLX) Clone() *DLX {
+	clone := *dl
+
+	// Nodes refer to each other by index, so copying the slices
+	// copies the links as well.
+	clone.entries = append([]entryNode(nil), dl.entries...)
+	clone.items = append([]itemNode(nil), dl.items...)
+
+	clone.options = append([]int32(nil), dl.options...)
+	clone.selected = append([]int{}, dl.selected...)
+	clone.disabled = append([]int(nil), dl.disabled...)
+	clone.hidden = append([]int(nil), dl.hidden...)
+	clone.costs = append([]int(nil), dl.costs...)
+	clone.payloads = append([]any(nil), dl.payloads...)
+	clone.symmetryPrev = append([]int(nil), dl.symmetryPrev...)
+
+	// The compiled conflicts are never modified, so the copies can
+	// share them.
+	clone.conflicts = dl.conflicts
+
+	if dl.rng != nil {
+		clone.rng = rand.New(rand.NewSource(dl.rng.Int63()))
+	}
+	if dl.tieRng != nil {
+		clone.tieRng = rand.New(rand.NewSource(dl.tieRng.Int63()))
+	}
+	clone.ctx = nil
+	clone.spareStages = nil
+	clone.buckets = nil
+
+	return &clone
+}
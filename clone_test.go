@@ -0,0 +1,27 @@
+package dancinglinks
+
+import (
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	dl.ForceOptions(0)
+
+	clone := dl.Clone()
+	dl.UnforceOptions()
+	dl.ForceOptions(4)
+
+	testExample(t, clone.AllSolutions(), [][]Step{
+		[]Step{Step{1, 6, []int{6}, 0, 5, "", ""}, Step{0, 4, []int{4, 5}, 1, 3, "", ""}},
+		[]Step{Step{1, 6, []int{6}, 0, 5, "", ""}, Step{0, 5, []int{4, 5}, 1, 3, "", ""}},
+	})
+
+	clone.UnforceOptions()
+	testExample(t, clone.AllSolutions(), classicDuplicates.solution)
+
+	testExample(t, dl.AllSolutions(), [][]Step{
+		[]Step{Step{1, 6, []int{6}, 0, 4, "", ""}, Step{2, 0, []int{0, 1}, 1, 2, "", ""}},
+		[]Step{Step{1, 6, []int{6}, 0, 4, "", ""}, Step{2, 1, []int{0, 1}, 1, 2, "", ""}},
+	})
+}
@@ -0,0 +1,157 @@
+package dancinglinks
+
+// The options each option conflicts with, i.e. cannot be selected
+// along with, stored contiguously by option.
+type conflictSets struct {
+	// The conflicts of option i are options[start[i]:start[i+1]],
+	// including option i itself.
+	start   []int32
+	options []int32
+
+	// The entries to unlink for the k-th conflict are
+	// entries[spans[k]:spans[k+1]]: those of the conflicting option
+	// whose items the choosing option does not cover.
+	spans   []int32
+	entries []int32
+}
+
+// Compile precomputes, for each option, the options it conflicts with
+// and the entries to unlink to rule them out, so that selecting an
+// option during a search goes through a ready-made list instead of
+// walking the columns of its items.  The lists take memory growing
+// with the number of conflicting pairs, which is quadratic in the size
+// of the columns, and still include the options ruled out already,
+// which column walks skip; whether they pay off depends on the problem
+// and how deep its searches go, so benchmark before committing to
+// them.  Compile panics if the lists hold more entries than a DLX can.
+//
+// Adding options or items discards the precomputed conflicts; call
+// Compile again afterwards.
+func (dl *DLX) Compile() {
+	// Forced and disabled options must be undone the way they were
+	// applied, so retract them before switching over.
+	resume := dl.suspend()
+	defer resume()
+
+	// The options covering each item, regardless of what is currently
+	// forced or disabled.
+	columnStart := make([]int, len(dl.items)+1)
+	for e := range dl.entries {
+		if entry := &dl.entries[e]; entry.option != -1 {
+			columnStart[entry.item+1]++
+		}
+	}
+	for i := range dl.items {
+		columnStart[i+1] += columnStart[i]
+	}
+	column := make([]int32, columnStart[len(dl.items)])
+	fill := append([]int(nil), columnStart[:len(dl.items)]...)
+	for e := range dl.entries {
+		if entry := &dl.entries[e]; entry.option != -1 {
+			column[fill[entry.item]] = int32(e)
+			fill[entry.item]++
+		}
+	}
+
+	c := &conflictSets{start: make([]int32, 1, len(dl.options)+1), spans: []int32{0}}
+
+	// The option last found to conflict with each option, and to
+	// cover each item, to skip duplicates.
+	seen := make([]int, len(dl.options))
+	covers := make([]int, len(dl.items))
+	for i := range seen {
+		seen[i] = -1
+	}
+	for i := range covers {
+		covers[i] = -1
+	}
+
+	for option := range dl.options {
+		for e := range dl.row(option) {
+			covers[dl.entries[e].item] = option
+		}
+		for e := range dl.row(option) {
+			entry := &dl.entries[e]
+			for _, other := range column[columnStart[entry.item]:columnStart[entry.item+1]] {
+				conflict := &dl.entries[other]
+				if conflict.option != int32(option) && entry.color != 0 && conflict.color == entry.color {
+					continue
+				}
+				if seen[conflict.option] == option {
+					continue
+				}
+				seen[conflict.option] = option
+
+				c.options = append(c.options, conflict.option)
+				for f := range dl.row(int(conflict.option)) {
+					if covers[dl.entries[f].item] != option {
+						c.entries = append(c.entries, int32(f))
+					}
+				}
+				checkEntryCount(len(c.entries), len(c.options))
+				c.spans = append(c.spans, int32(len(c.entries)))
+			}
+		}
+		c.start = append(c.start, int32(len(c.options)))
+	}
+	dl.conflicts = c
+}
+
+// Selects an option by ruling out its precomputed conflicts, to the
+// same effect as covering and purifying its items.
+func (dl *DLX) chooseCompiled(index int) {
+	for e := range dl.row(index) {
+		entry := &dl.entries[e]
+		switch {
+		case entry.color == 0:
+			dl.unlinkItem(int(entry.item))
+		case dl.items[entry.item].purifier == -1:
+			dl.items[entry.item].purifier = e
+		}
+	}
+
+	c := dl.conflicts
+	for k := c.start[index]; k < c.start[index+1]; k++ {
+		option := c.options[k]
+		dl.hidden[option]++
+		if dl.hidden[option] > 1 {
+			continue
+		}
+		for _, e := range c.entries[c.spans[k]:c.spans[k+1]] {
+			if !dl.agrees(int(e)) {
+				dl.unlinkEntry(int(e))
+			}
+		}
+	}
+}
+
+func (dl *DLX) unchooseCompiled(index int) {
+	c := dl.conflicts
+	for k := c.start[index+1] - 1; k >= c.start[index]; k-- {
+		option := c.options[k]
+		dl.hidden[option]--
+		if dl.hidden[option] > 0 {
+			continue
+		}
+		entries := c.entries[c.spans[k]:c.spans[k+1]]
+		for i := len(entries) - 1; i >= 0; i-- {
+			if !dl.agrees(int(entries[i])) {
+				dl.relinkEntry(int(entries[i]))
+			}
+		}
+	}
+
+	first := int(dl.options[index])
+	for e := int(dl.entries[first].left); ; e = int(dl.entries[e].left) {
+		entry := &dl.entries[e]
+		switch {
+		case entry.color == 0:
+			dl.relinkItem(int(entry.item))
+		case dl.items[entry.item].purifier == e:
+			dl.items[entry.item].purifier = -1
+		}
+		if e == first {
+			break
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	const A, B = 1, 2
+	itemCount, options := latinSquare(4)
+	problems := []func() *DLX{
+		classic.toDLX,
+		classicDuplicates.toDLX,
+		impossible.toDLX,
+		func() *DLX { return queens(6) },
+		func() *DLX { return New(itemCount, options) },
+		func() *DLX {
+			return NewColored(3, 2, [][]ColoredItem{
+				{{0, 0}, {1, 0}, {3, 0}, {4, A}},
+				{{0, 0}, {2, 0}, {3, A}, {4, 0}},
+				{{0, 0}, {3, B}},
+				{{1, 0}, {3, A}},
+				{{2, 0}, {4, B}},
+			})
+		},
+	}
+
+	for i, problem := range problems {
+		want, dl := problem().AllSolutions(), problem()
+		dl.Compile()
+		if got := dl.AllSolutions(); !reflect.DeepEqual(got, want) {
+			t.Errorf("problem %d: should find %v, found %v", i, want, got)
+		}
+		if !dl.Equal(problem()) {
+			t.Errorf("problem %d: should be restored after searching, got %v", i, dl)
+		}
+	}
+
+	// Forcing and disabling options work the same way.
+	dl := New(itemCount, options)
+	dl.Compile()
+	dl.DisableOption(1)
+	dl.ForceOptions(0, 17)
+	plain := New(itemCount, options)
+	plain.DisableOption(1)
+	plain.ForceOptions(0, 17)
+	if got, want := dl.AllSolutions(), plain.AllSolutions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("should find %v with forced options, found %v", want, got)
+	}
+	if got, want := dl.ActiveOptions(), plain.ActiveOptions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("should leave %v active, left %v", want, got)
+	}
+
+	// Options forced before compiling are retracted correctly.
+	for k := range classicDuplicates.options {
+		dl := classicDuplicates.toDLX()
+		dl.ForceOptions(k)
+		dl.Compile()
+		dl.UnforceOptions()
+		if got, want := dl.AllSolutions(), classicDuplicates.toDLX().AllSolutions(); !reflect.DeepEqual(got, want) {
+			t.Errorf("option %d: should find %v after unforcing, found %v", k, want, got)
+		}
+	}
+
+	// Adding options discards the compiled conflicts.
+	dl.AddOption([]int{0})
+	if dl.conflicts != nil {
+		t.Errorf("should discard the compiled conflicts")
+	}
+}
+
+func BenchmarkCompile(b *testing.B) {
+	itemCount, options := latinSquare(5)
+	for _, compiled := range []bool{false, true} {
+		name := "columns"
+		if compiled {
+			name = "compiled"
+		}
+		b.Run(name, func(b *testing.B) {
+			dl := New(itemCount, options)
+			if compiled {
+				dl.Compile()
+			}
+			for i := 0; i < b.N; i++ {
+				dl.CountSolutions()
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package dancinglinks
+
+import (
+	"context"
+	"math/big"
+)
+
+// Runs a search with the given context, returning the error that cut
+// it short, if any.
+func (dl *DLX) withContext(ctx context.Context, search func()) error {
+	defer func(ctx context.Context) { dl.ctx = ctx }(dl.ctx)
+	dl.ctx = ctx
+
+	search()
+	return dl.searchErr()
+}
+
+// GenerateSolutionsContext is like GenerateSolutions, but stops once
+// ctx is done, returning ctx.Err().  It also returns ErrNodeLimit or
+// ErrTimeout if the search runs out of budget.
+func (dl *DLX) GenerateSolutionsContext(ctx context.Context, yield func([]Step) bool) error {
+	return dl.withContext(ctx, func() { dl.GenerateSolutions(yield) })
+}
+
+// GenerateCoversContext is like GenerateCovers, but stops once ctx is
+// done, returning ctx.Err().
+func (dl *DLX) GenerateCoversContext(ctx context.Context, yield func([]int) bool) error {
+	return dl.withContext(ctx, func() { dl.GenerateCovers(yield) })
+}
+
+// AllSolutionsContext is like AllSolutions, but stops once ctx is
+// done, returning the solutions found so far along with ctx.Err().
+func (dl *DLX) AllSolutionsContext(ctx context.Context) ([][]Step, error) {
+	var solutions [][]Step
+	err := dl.withContext(ctx, func() { solutions = dl.AllSolutions() })
+	return solutions, err
+}
+
+// AllCoversContext is like AllCovers, but stops once ctx is done,
+// returning the covers found so far along with ctx.Err().
+func (dl *DLX) AllCoversContext(ctx context.Context) ([][]int, error) {
+	var covers [][]int
+	err := dl.withContext(ctx, func() { covers = dl.AllCovers() })
+	return covers, err
+}
+
+// AnySolutionContext is like AnySolution, but gives up once ctx is
+// done, returning ctx.Err().
+func (dl *DLX) AnySolutionContext(ctx context.Context) ([]Step, error) {
+	var solution []Step
+	err := dl.withContext(ctx, func() { solution = dl.AnySolution() })
+	if err != nil {
+		return nil, err
+	}
+	return solution, nil
+}
+
+// AnyCoverContext is like AnyCover, but gives up once ctx is done,
+// returning ctx.Err().
+func (dl *DLX) AnyCoverContext(ctx context.Context) ([]int, error) {
+	var cover []int
+	err := dl.withContext(ctx, func() { cover = dl.AnyCover() })
+	if err != nil {
+		return nil, err
+	}
+	return cover, nil
+}
+
+// CountSolutionsContext is like CountSolutions, but stops once ctx is
+// done, returning the partial count along with ctx.Err().
+func (dl *DLX) CountSolutionsContext(ctx context.Context) (*big.Int, error) {
+	var count *big.Int
+	err := dl.withContext(ctx, func() { count = dl.CountSolutions() })
+	return count, err
+}
@@ -0,0 +1,29 @@
+package dancinglinks
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	count := 0
+	err := classicDuplicates.toDLX().GenerateSolutionsContext(ctx, func([]Step) bool {
+		count++
+		return true
+	})
+	if err != nil || count != 4 {
+		t.Errorf("should find 4 solutions without error, found %d (err=%v)", count, err)
+	}
+
+	cancel()
+	solution, err := classic.toDLX().AnySolutionContext(ctx)
+	if solution != nil || err != context.Canceled {
+		t.Errorf("should be canceled, got %v (err=%v)", solution, err)
+	}
+
+	covers, err := impossible.toDLX().AllCoversContext(context.Background())
+	if len(covers) != 0 || err != nil {
+		t.Errorf("should find no covers without error, got %v (err=%v)", covers, err)
+	}
+}
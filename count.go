@@ -0,0 +1,115 @@
+package dancinglinks
+
+import (
+	"math/big"
+)
+
+// CountSolutions counts the solutions without materializing them.
+// Apart from the result, counting does not allocate: it walks the
+// linked structure directly instead of building steps.  If the node
+// limit is exceeded, the count covers only the part of the search
+// space explored, and Err returns ErrNodeLimit.
+func (dl *DLX) CountSolutions() *big.Int {
+	total := new(big.Int)
+	if !dl.checkCoverable() {
+		return total
+	}
+
+	c := counter{dl: dl}
+	if !dl.searchCovers(c.add) {
+		c.search()
+	}
+
+	// Every time the machine-word count wrapped around, it lost 2^64
+	// solutions.
+	total.Lsh(total.SetUint64(c.wraps), 64)
+	return total.Add(total, new(big.Int).SetUint64(c.count))
+}
+
+// CountSolutionsUpTo counts solutions, but stops as soon as it has
+// found n of them, reporting whether it did.  This cheaply tells apart
+// problems with no, one, or several solutions.
+func (dl *DLX) CountSolutionsUpTo(n int) (count int, reached bool) {
+	if n <= 0 {
+		return 0, true
+	}
+	if !dl.checkCoverable() {
+		return 0, false
+	}
+
+	c := counter{dl: dl, limit: uint64(n)}
+	if !dl.searchCovers(c.add) {
+		c.search()
+	}
+	return int(c.count), c.count == c.limit
+}
+
+// Bookkeeping for counting solutions.
+type counter struct {
+	dl *DLX
+
+	// Solution count, modulo 2^64, and the number of times it wrapped
+	// around.
+	count uint64
+	wraps uint64
+
+	// Count at which to stop, or zero to count everything.
+	limit uint64
+
+	// Options chosen so far, kept only to check symmetries.
+	path []Step
+
+	// The number of options chosen so far, for the depth limit.
+	depth int
+}
+
+func (c *counter) search() {
+	dl := c.dl
+	item := dl.nextItem()
+	if item == root {
+		if dl.symmetryHolds(c.path) {
+			c.add(nil)
+		}
+		return
+	}
+
+	// Past the depth limit, there is no room left to cover the
+	// remaining items.
+	if dl.depthLimit > 0 && c.depth >= dl.depthLimit {
+		dl.depthCutoff = true
+		return
+	}
+
+	// Choosing and then unchoosing an option leaves the column exactly
+	// as it was, so we can walk it while the search dances around.
+	head := dl.items[item].head
+	for entry := int(dl.entries[head].down); entry != head; entry = int(dl.entries[entry].down) {
+		if c.limit > 0 && c.count == c.limit || !dl.visit() {
+			break
+		}
+
+		option := int(dl.entries[entry].option)
+		dl.chooseOption(option)
+		c.depth++
+		switch {
+		case dl.deadEnd():
+		case dl.symmetryPrev == nil:
+			c.search()
+		case dl.symmetryAllows(option, c.path):
+			c.path = append(c.path, Step{Option: option})
+			c.search()
+			c.path = c.path[:len(c.path)-1]
+		}
+		c.depth--
+		dl.unchooseOption(option)
+	}
+}
+
+// Counts a solution, reporting whether to go on counting.
+func (c *counter) add([]int) bool {
+	c.count++
+	if c.count == 0 {
+		c.wraps++
+	}
+	return c.limit == 0 || c.count < c.limit
+}
@@ -0,0 +1,70 @@
+package dancinglinks
+
+import (
+	"testing"
+)
+
+func TestCountSolutions(t *testing.T) {
+	for _, e := range []example{
+		classic,
+		classicDuplicates,
+		impossible,
+		trivial,
+	} {
+		count := e.toDLX().CountSolutions()
+		if !count.IsInt64() || count.Int64() != int64(len(e.solution)) {
+			t.Errorf("should count %d solutions, counted %v", len(e.solution), count)
+		}
+	}
+
+	dl := classicDuplicates.toDLX()
+	dl.AddSymmetry(0, 1)
+	if count := dl.CountSolutions(); count.Int64() != 2 {
+		t.Errorf("should count 2 solutions up to symmetry, counted %v", count)
+	}
+}
+
+func TestCountSolutionsAllocs(t *testing.T) {
+	// Each of the 10 items is covered by two duplicate singletons, for
+	// 1024 solutions.
+	options := [][]int{}
+	for item := 0; item < 10; item++ {
+		options = append(options, []int{item}, []int{item})
+	}
+	dl := New(10, options)
+
+	// Only the result and per-depth scratch space should be allocated,
+	// not anything per search node or solution.
+	if allocs := testing.AllocsPerRun(10, func() { dl.CountSolutions() }); allocs > 50 {
+		t.Errorf("counting should barely allocate, allocated %v times", allocs)
+	}
+}
+
+func BenchmarkCountSolutions(b *testing.B) {
+	dl := classicDuplicates.toDLX()
+	for i := 0; i < b.N; i++ {
+		dl.CountSolutions()
+	}
+}
+
+func TestCountSolutionsUpTo(t *testing.T) {
+	for _, test := range []struct {
+		e       example
+		n       int
+		count   int
+		reached bool
+	}{
+		{classicDuplicates, 2, 2, true},
+		{classicDuplicates, 4, 4, true},
+		{classicDuplicates, 5, 4, false},
+		{classic, 2, 1, false},
+		{impossible, 1, 0, false},
+		{trivial, 1, 1, true},
+	} {
+		count, reached := test.e.toDLX().CountSolutionsUpTo(test.n)
+		if count != test.count || reached != test.reached {
+			t.Errorf("up to %d: should be %d (reached=%v), got %d (reached=%v)",
+				test.n, test.count, test.reached, count, reached)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package dancinglinks
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadMatrixCSV reads a dense 0/1 matrix in CSV form, with a row for
+// every option and a column for every item, as FromMatrix takes it.
+// If header is set, the first row names the items, which labels them.
+// Every row must have the same number of cells, each 0 or 1, possibly
+// surrounded by spaces.
+func ReadMatrixCSV(r io.Reader, header bool) (*DLX, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []string
+	if header {
+		if len(records) == 0 {
+			return nil, fmt.Errorf("dancinglinks: missing header row")
+		}
+		items, records = records[0], records[1:]
+	}
+
+	matrix := make([][]bool, len(records))
+	for i, record := range records {
+		matrix[i] = make([]bool, len(record))
+		for j, cell := range record {
+			switch strings.TrimSpace(cell) {
+			case "0":
+			case "1":
+				matrix[i][j] = true
+			default:
+				return nil, fmt.Errorf("dancinglinks: row %d, column %d: cell %q is neither 0 nor 1", i+1, j+1, cell)
+			}
+		}
+	}
+
+	// Without options, only the header tells the number of items.
+	dl := New(len(items), nil)
+	if len(matrix) > 0 {
+		dl = FromMatrix(matrix)
+	}
+	if header {
+		seen := map[string]bool{}
+		for _, name := range items {
+			if seen[name] {
+				return nil, fmt.Errorf("dancinglinks: duplicate item %q", name)
+			}
+			seen[name] = true
+		}
+		dl.itemLabels = items
+	}
+	return dl, nil
+}
+
+// WriteMatrixCSV writes the original matrix of the problem, as
+// rendered by ToMatrixState with MatrixOriginal, in CSV form with cells
+// 0 or 1.  If header is set, the first row names the items.
+func (dl *DLX) WriteMatrixCSV(w io.Writer, header bool) error {
+	cw := csv.NewWriter(w)
+	record := make([]string, len(dl.items))
+	if header {
+		for i := range record {
+			record[i] = dl.ItemLabel(i)
+		}
+		cw.Write(record)
+	}
+	for _, row := range dl.ToMatrixState(MatrixOriginal) {
+		for i, cell := range row {
+			record[i] = "0"
+			if cell {
+				record[i] = "1"
+			}
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
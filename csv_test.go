@@ -0,0 +1,59 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatrixCSV(t *testing.T) {
+	dl, err := ReadMatrixCSV(strings.NewReader("a,b,c\n1,0,1\n0, 1 ,0\n1,1,0\n"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if covers := dl.AllCovers(); !reflect.DeepEqual(covers, [][]int{{0, 1}}) {
+		t.Errorf("should be [[0 1]], got %v", covers)
+	}
+	if label := dl.ItemLabel(2); label != "c" {
+		t.Errorf("item 2 should be c, got %q", label)
+	}
+
+	b := &strings.Builder{}
+	if err := dl.WriteMatrixCSV(b, true); err != nil {
+		t.Fatal(err)
+	}
+	if want := "a,b,c\n1,0,1\n0,1,0\n1,1,0\n"; b.String() != want {
+		t.Errorf("should write %q, wrote %q", want, b.String())
+	}
+
+	b.Reset()
+	if err := classic.toDLX().WriteMatrixCSV(b, false); err != nil {
+		t.Fatal(err)
+	}
+	dl, err = ReadMatrixCSV(strings.NewReader(b.String()), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dl.Equal(classic.toDLX()) {
+		t.Errorf("should read back %v, got %v", classic.toDLX(), dl)
+	}
+
+	dl, err = ReadMatrixCSV(strings.NewReader("a,b\n"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := dl.Size(); size.Items != 2 || size.Options != 0 {
+		t.Errorf("should have 2 items and no options, got %+v", size)
+	}
+
+	for _, input := range []string{
+		"",
+		"a,a\n1,0\n",
+		"a,b\n1,0,1\n",
+		"1,2\n",
+	} {
+		if _, err := ReadMatrixCSV(strings.NewReader(input), input != "1,2\n"); err == nil {
+			t.Errorf("should reject %q", input)
+		}
+	}
+}
@@ -1,26 +1,148 @@
 package dancinglinks
 
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math"
+	"math/rand"
+	"time"
+)
+
 // The setup for an exact cover problem, which consists of (1) a set
 // of items to cover and (2) a collection of options, i.e. subsets of
 // the items.  The exact cover problem solver returns a selection of
 // the option such that each item is contained in exactly one of the
 // selected options.
 type DLX struct {
-	// A list of options.  Each "option" is a list of pointers to the
-	// entries provided by that option.
-	options [][]*entryNode
+	// All entries, i.e. the column headers followed by the entries of
+	// each option, stored contiguously and linked by index.
+	entries []entryNode
+
+	// The first entry of each option, or -1 if the option has none.
+	// The entries of an option are linked in a cycle by their left and
+	// right links, in the order of the option's items.
+	options []int32
 
 	// Blank anchor node, whose `right` points to the first/left-most
-	// item to be covered.
-	itemHead *itemNode
+	// item to be covered.  Links refer to it by the index `root`.
+	itemHead itemNode
+
+	// All items, indexed by item index.  Primary items come first,
+	// followed by secondary items, which are never linked into the
+	// `itemHead` list since they need not be covered.
+	items []itemNode
+
+	// The number of primary items in the `itemHead` list, i.e. left to
+	// cover.
+	uncovered int
 
 	// Indices of required options, i.e. options that are required to be
 	// in the selection.
 	selected []int
 
-	// Indices of options that were removed when selecting the
-	// pre-selected/required options.
-	deleted []int
+	// Indices of disabled options, which are excluded from the search
+	// without being selected, in the order they were disabled.
+	disabled []int
+
+	// Cost of each option, used when searching for a minimum-cost
+	// cover.  Options past the end of the slice cost nothing.
+	costs []int
+
+	// Arbitrary data attached to each option.
+	payloads []any
+
+	// Names of the items and options, or nil if unlabeled.
+	itemLabels   []string
+	optionLabels []string
+
+	// Identifiers of the items, and their indices by identifier, or
+	// nil if the items were given by index.
+	itemIDs     []uint64
+	itemIndices map[uint64]int
+
+	// Reason the most recent search was cut short, if any.
+	err error
+
+	// For each option belonging to a symmetry group, the option
+	// preceding it in the group, or -1.  Nil if no symmetries have
+	// been declared.
+	symmetryPrev []int
+
+	// Source of randomness for branching, or nil to branch
+	// deterministically.
+	rng *rand.Rand
+
+	// Source of randomness for TieRandom, kept apart from rng so that
+	// breaking ties at random does not shuffle the choices.
+	tieRng *rand.Rand
+
+	// The order in which to enumerate solutions.
+	order Order
+
+	// The data structure to search with.
+	engine Engine
+
+	// Whether yielded steps leave out their Choices.
+	omitChoices bool
+
+	// Search tree nodes left over from earlier searches, for reuse.
+	spareStages []*stage
+
+	// How to choose among items tied for the fewest remaining
+	// choices, and the callback to do so with for TieFunc.
+	tieBreak TieBreak
+	tieFunc  func(items []int) int
+
+	// For each item, its rank in the order set by SetItemOrder, which
+	// breaks ties in place of the index, or nil to go by index.
+	itemRank []int
+
+	// Maximum number of search nodes to visit per search, or zero for
+	// no limit, the number visited so far, and how many of them turned
+	// out to be dead ends.
+	nodeLimit int
+	nodes     int
+	deadEnds  int
+
+	// Maximum number of options a search may select, or zero for no
+	// limit, and whether the limit cut off any part of the most recent
+	// search.
+	depthLimit  int
+	depthCutoff bool
+
+	// Time at which searches give up, or zero for no deadline.
+	deadline time.Time
+
+	// Context of the ongoing search, if any, whose cancellation stops
+	// it.
+	ctx context.Context
+
+	// Counter incremented whenever some item's remaining choices
+	// change, to tell which items were touched last.
+	clock uint64
+
+	// The options each option conflicts with, precomputed by Compile,
+	// or nil to find them by walking columns.
+	conflicts *conflictSets
+
+	// For each option, the number of covers and deletions currently
+	// hiding it.  Options are available when nothing hides them.
+	hidden []int
+
+	// The items left to cover, by remaining choices, or nil if not (yet)
+	// kept for this problem.
+	buckets *itemBuckets
+
+	// The number of items left to cover without any choices left, as
+	// counted since the search started with none, so that the search
+	// can back out of a dead end right away.
+	starved int
+
+	// Whether starved counts every such item, as it does from the
+	// moment a search checks the problem until the structure changes
+	// by other means than covering and hiding.
+	starvedExact bool
 }
 
 // A decision step in the exact cover solution path.  At each step,
@@ -28,162 +150,373 @@ type DLX struct {
 // options and selects one of the options that cover the item.  A Step
 // records the item covered in that step, the option selected to cover
 // that item, and all the remaining available options that cover the
-// item.
+// item, as well as where in the search the step was taken.
 type Step struct {
 	// Index of the item to be covered by this step.
-	Item int
+	Item int `json:"item"`
 
 	// Index of the option selected in this step to cover the item.
 	// Option is guaranteed to be an element of Choices.
-	Option int
+	Option int `json:"option"`
 
 	// All (remaining) available options that cover the item.  Choices
-	// is guaranteed to contain Option.
-	Choices []int
+	// is guaranteed to contain Option, unless left out altogether with
+	// SetOmitChoices, in which case it is nil.
+	Choices []int `json:"choices"`
+
+	// Number of steps preceding this one in the solution.
+	Depth int `json:"depth"`
+
+	// Number of primary items left to cover when this step was taken,
+	// including Item itself.
+	Remaining int `json:"remaining"`
+
+	// Names of the item and the option, if the problem is labeled, or
+	// empty otherwise.
+	ItemLabel   string `json:"itemLabel,omitempty"`
+	OptionLabel string `json:"optionLabel,omitempty"`
 }
 
 // A linked list node storing an item in an exact cover setup.
 type itemNode struct {
-	// The index of the item.
-	index int
+	// Linked list neighbors, by item index.
+	left  int
+	right int
 
-	// Linked list neighbors.
-	left  *itemNode
-	right *itemNode
+	// Index of the blank "anchor" entry node whose `down` points to
+	// the first/top-most entry covering this item.
+	head int
 
-	// Blank "anchor" entry node whose `down` points to the
-	// first/top-most entry covering this item.
-	head *entryNode
+	// Index of the entry whose color the item has been purified to, or
+	// -1 if the item is not purified.
+	purifier int
 
 	// Number of (remaining) entries that cover the item.  At each
 	// iteration the dancing links algorithm chooses the item with the
 	// fewest options covering it.
 	choices int
+
+	// Value of the DLX's clock when `choices` last changed.
+	touched uint64
+
+	// Whether the item is secondary, i.e. may be covered at most once
+	// (or, if colored, by any number of options that agree on its
+	// color) but need not be covered at all.
+	secondary bool
+
+	// Whether the item is soft, i.e. may be left uncovered by the
+	// optimizing solvers at a cost of `penalty`.
+	soft    bool
+	penalty int
 }
 
 // A linked list node storing an entry (a 1 in the exact cover matrix)
-// in the exact cover setup.
+// in the exact cover setup.  Indices and colors are stored in 32 bits,
+// halving the size of the nodes, which limits a DLX to maxEntries
+// entries.
 type entryNode struct {
-	// The item covered by this entry.
-	item *itemNode
+	// The index of the item covered by this entry.
+	item int32
 
 	// The index of the option this entry belongs to.
-	option int
+	option int32
+
+	// Linked list neighbors, by entry index: up and down within the
+	// item's column, and left and right within the option's row.
+	up    int32
+	down  int32
+	left  int32
+	right int32
+
+	// The color assigned to the item by this entry's option, if the
+	// item is secondary.  Zero means uncolored.
+	color int32
+}
 
-	// Linked list neighbors.
-	up   *entryNode
-	down *entryNode
+// The most entries, column headers included, and options a DLX can
+// hold.
+const maxEntries = math.MaxInt32
+
+// Panics if a DLX would hold more entries or options than fit in the
+// nodes' indices.
+func checkEntryCount(entries, options int) {
+	if entries > maxEntries || options > maxEntries {
+		panic(fmt.Sprintf("dancinglinks: %d entries and %d options exceed the limit of %d", entries, options, maxEntries))
+	}
 }
 
+// Panics if a color does not fit in an entry, and otherwise returns it
+// as stored.
+func checkColor(color int) int32 {
+	if color < math.MinInt32 || color > math.MaxInt32 {
+		panic(fmt.Sprintf("dancinglinks: color %d out of range [%d, %d]", color, math.MinInt32, math.MaxInt32))
+	}
+	return int32(color)
+}
+
+// The index by which links refer to the item list's anchor node.
+const root = -1
+
+// The item node with the given index, or the anchor node for root.
+func (dl *DLX) item(index int) *itemNode {
+	if index == root {
+		return &dl.itemHead
+	}
+	return &dl.items[index]
+}
+
+// An item of an option in a colored exact cover (XCC) problem,
+// together with the color the option assigns to it.  A color of zero
+// means uncolored; only secondary items may be colored.
+type ColoredItem struct {
+	Item  int
+	Color int
+}
+
+// UncoverableError reports items that no remaining option covers,
+// which rules out any exact cover before the search even starts.
+type UncoverableError struct {
+	Items []int
+}
+
+func (e *UncoverableError) Error() string {
+	return fmt.Sprintf("dancinglinks: no remaining option covers items %v", e.Items)
+}
+
+// A node of the search tree, recording the item to cover and the
+// moves available to do so.
 type stage struct {
-	item    int
-	parent  int
-	deleted []int
+	// The item to cover and the options that cover it, and the number
+	// of primary items left to cover.
+	item      int
+	options   []int
+	remaining int
+
+	// The move leading to this stage.
+	parent int
+
+	// The moves to try, and the index of the next one.  A move is
+	// either the index of an option to select, or for an option that
+	// is excluded instead, its bitwise complement.
 	choices []int
 	i       int
+
+	// Storage for the moves when branching on a single option.
+	moves [2]int
+}
+
+// New sets up an exact cover problem over items 0 through
+// itemCount-1, configured by the given settings.
+func New(itemCount int, options [][]int, settings ...Setting) *DLX {
+	dl := newDLX(itemCount, 0, options, nil)
+	for _, setting := range settings {
+		setting(dl)
+	}
+	return dl
+}
+
+// NewColored sets up a colored exact cover (XCC) problem.  Items
+// 0 through primaryCount-1 are primary and must be covered exactly
+// once; the following secondaryCount items are secondary and need not
+// be covered.  An uncolored secondary item may be covered at most
+// once, while a colored one may be covered by any number of selected
+// options, provided they all assign it the same color.  Colors must
+// fit in 32 bits.
+func NewColored(primaryCount, secondaryCount int, options [][]ColoredItem) *DLX {
+	entryCount := 0
+	for _, option := range options {
+		entryCount += len(option)
+	}
+
+	// Split the options into items and colors, each sharing one
+	// backing array rather than allocating per option.
+	plain := make([][]int, len(options))
+	colors := make([][]int, len(options))
+	itemStore := make([]int, 0, entryCount)
+	colorStore := make([]int, 0, entryCount)
+	for i, option := range options {
+		start := len(itemStore)
+		for _, entry := range option {
+			itemStore = append(itemStore, entry.Item)
+			colorStore = append(colorStore, entry.Color)
+		}
+		end := len(itemStore)
+		plain[i] = itemStore[start:end:end]
+		colors[i] = colorStore[start:end:end]
+	}
+	return newDLX(primaryCount, secondaryCount, plain, colors)
+}
+
+func newDLX(primaryCount, secondaryCount int, options [][]int, colors [][]int) *DLX {
+	entryCount := 0
+	for _, optionItems := range options {
+		entryCount += len(optionItems)
+	}
+	return buildDLX(primaryCount, secondaryCount, len(options), entryCount, func(i int, _ []int) []int {
+		return options[i]
+	}, colors)
 }
 
-func New(itemCount int, options [][]int) *DLX {
+// Sets up a problem with the given numbers of options and entries, with
+// the items of each option given by generate, which may append them
+// to the given buffer.
+func buildDLX(primaryCount, secondaryCount, optionCount, entryCount int, generate func(i int, buf []int) []int, colors [][]int) *DLX {
+	itemCount := primaryCount + secondaryCount
+	checkEntryCount(itemCount+entryCount, optionCount)
+
 	dl := &DLX{
-		options:  make([][]*entryNode, len(options)),
-		itemHead: &itemNode{index: -1},
+		entries:  make([]entryNode, itemCount, itemCount+entryCount),
+		options:  make([]int32, optionCount),
+		hidden:   make([]int, optionCount),
+		itemHead: itemNode{left: root, right: root, head: -1},
+		items:    make([]itemNode, itemCount),
 		selected: []int{},
-		deleted:  []int{},
 	}
 
-	// Construct item list.
-	items := make([]*itemNode, itemCount)
-	lastItem := dl.itemHead
-	for index := range items {
-		newItem := &itemNode{
-			index: index,
-			left:  lastItem,
-			head:  &entryNode{option: -1},
+	// Construct item list, with the column headers taking up the first
+	// entries.
+	lastItem := root
+	for index := range dl.items {
+		item := &dl.items[index]
+		item.head = index
+		item.purifier = -1
+		item.secondary = index >= primaryCount
+		dl.entries[index] = entryNode{item: int32(index), option: -1, up: int32(index), down: int32(index)}
+
+		// Secondary items stay out of the linked list, pointing only
+		// to themselves.
+		if item.secondary {
+			item.left = index
+			item.right = index
+			continue
 		}
 
-		// Add item to slice.
-		items[index] = newItem
-
 		// Append to linked list.
-		lastItem.right = newItem
-		lastItem = newItem
+		item.left = lastItem
+		dl.item(lastItem).right = index
+		lastItem = index
 	}
 
 	// Make linked list cyclic to reduce edge cases.
-	lastItem.right = dl.itemHead
+	dl.item(lastItem).right = root
 	dl.itemHead.left = lastItem
-
-	// Keep track of bottom-most node for each column (item).
-	lastEntries := make([]*entryNode, itemCount)
-	for itemIndex, item := range items {
-		lastEntries[itemIndex] = item.head
-	}
-
-	// Create and append entry nodes.
-	for option, optionItems := range options {
-		for _, itemIndex := range optionItems {
-			newEntry := &entryNode{
-				item:   items[itemIndex],
-				option: option,
-				up:     lastEntries[itemIndex],
+	dl.uncovered = primaryCount
+
+	// Create and append entry nodes, each option's entries following
+	// each other.
+	var optionItems []int
+	for option := 0; option < optionCount; option++ {
+		optionItems = generate(option, optionItems[:0])
+		start := len(dl.entries)
+		if start+len(optionItems) > itemCount+entryCount {
+			panic("dancinglinks: options cover more items than counted")
+		}
+		for i, itemIndex := range optionItems {
+			index := len(dl.entries)
+			head := dl.items[itemIndex].head
+			entry := entryNode{
+				item:   int32(itemIndex),
+				option: int32(option),
+				up:     dl.entries[head].up,
+				down:   int32(head),
+				left:   int32(index - 1),
+				right:  int32(index + 1),
 			}
+			if colors != nil && dl.items[itemIndex].secondary {
+				entry.color = checkColor(colors[option][i])
+			}
+			dl.entries = append(dl.entries, entry)
 
-			newEntry.item.choices++
-
-			// Add entry to corresponding row (option) record.
-			dl.options[option] = append(dl.options[option], newEntry)
+			dl.items[itemIndex].choices++
 
 			// Append to column-specific linked list.
-			lastEntries[itemIndex].down = newEntry
-			lastEntries[itemIndex] = newEntry
+			dl.entries[entry.up].down = int32(index)
+			dl.entries[head].up = int32(index)
 		}
-	}
 
-	// Make column lists cyclic to reduce edge cases.
-	for index, item := range items {
-		lastEntries[index].down = item.head
-		item.head.up = lastEntries[index]
+		// Close the option's row into a cycle.
+		end := len(dl.entries)
+		if start == end {
+			dl.options[option] = -1
+			continue
+		}
+		dl.entries[start].left = int32(end - 1)
+		dl.entries[end-1].right = int32(start)
+		dl.options[option] = int32(start)
 	}
 
 	return dl
 }
 
-func FromMatrix(matrix [][]bool) *DLX {
+// FromSparse is like New, but infers the number of items from the
+// largest item index the options cover.
+func FromSparse(options [][]int) *DLX {
 	itemCount := 0
-	options := make([][]int, len(matrix))
+	for _, option := range options {
+		for _, item := range option {
+			if item >= itemCount {
+				itemCount = item + 1
+			}
+		}
+	}
+	return New(itemCount, options)
+}
 
-	for i, row := range matrix {
+func FromMatrix(matrix [][]bool) *DLX {
+	itemCount := 0
+	entryCount := 0
+	for _, row := range matrix {
 		if len(row) > itemCount {
 			itemCount = len(row)
 		}
+		for _, cell := range row {
+			if cell {
+				entryCount++
+			}
+		}
+	}
 
-		option := []int{}
+	// The options share one backing array.
+	options := make([][]int, len(matrix))
+	store := make([]int, 0, entryCount)
+	for i, row := range matrix {
+		start := len(store)
 		for j, cell := range row {
 			if cell {
-				option = append(option, j)
+				store = append(store, j)
 			}
 		}
-
-		options[i] = option
+		options[i] = store[start:len(store):len(store)]
 	}
 
 	return New(itemCount, options)
 }
 
+// ToMatrix returns a row for every option, including forced and ruled
+// out ones, with a column for each primary item left to cover followed
+// by the secondary items.  ToMatrixState renders the original or the
+// reduced matrix instead.
 func (dl *DLX) ToMatrix() [][]bool {
-	items := map[*itemNode]int{}
+	items := map[int]int{}
 	index := 0
-	for item := dl.itemHead.right; item != dl.itemHead; item = item.right {
+	for item := dl.itemHead.right; item != root; item = dl.items[item].right {
 		items[item] = index
 		index++
 	}
 
+	for item := range dl.items {
+		if dl.items[item].secondary {
+			items[item] = index
+			index++
+		}
+	}
+
 	mat := make([][]bool, len(dl.options))
 
-	for i, option := range dl.options {
+	for i := range dl.options {
 		row := make([]bool, len(items))
-		for _, entry := range option {
-			row[items[entry.item]] = true
+		for entry := range dl.row(i) {
+			row[items[int(dl.entries[entry].item)]] = true
 		}
 		mat[i] = row
 	}
@@ -191,88 +524,276 @@ func (dl *DLX) ToMatrix() [][]bool {
 	return mat
 }
 
+// ToOptions returns every option, including empty ones, as the list
+// of items it covers, such that New(dl.ItemCount(), dl.ToOptions())
+// sets up the same problem.  Forced options are not taken into
+// account.
+func (dl *DLX) ToOptions() [][]int {
+	options := make([][]int, len(dl.options))
+	for i := range dl.options {
+		options[i] = dl.Option(i)
+	}
+	return options
+}
+
+// ForceOptions selects the given options, in order, as part of every
+// solution.  Options no longer available, e.g. because they conflict
+// with options forced before, are skipped; ForceOptionsChecked
+// reports them instead.
 func (dl *DLX) ForceOptions(indices ...int) {
 	for _, index := range indices {
+		if dl.hidden[index] > 0 {
+			continue
+		}
+		dl.chooseOption(index)
 		dl.selected = append(dl.selected, index)
-		dl.chooseOption(index, &dl.deleted)
 	}
 }
 
 func (dl *DLX) UnforceOptions() {
-	dl.restoreOptions(dl.deleted)
-	dl.deleted = dl.deleted[:0]
-	dl.selected = dl.selected[:0]
+	dl.unforceDownTo(0)
 }
 
-func (dl *DLX) GenerateSolutions(yield func([]Step) bool) bool {
+// UnforceOption retracts a single forced option, keeping the others
+// forced.  Since forcing is undone in reverse order, options forced
+// after it are retracted and then forced again.  Options that are not
+// forced are left alone.
+func (dl *DLX) UnforceOption(index int) {
+	position := -1
+	for i, option := range dl.selected {
+		if option == index {
+			position = i
+		}
+	}
+	if position == -1 {
+		return
+	}
 
-	item, choices := dl.nextChoices()
-	if choices == nil {
-		yield([]Step{})
-		return true
+	later := append([]int{}, dl.selected[position+1:]...)
+	dl.unforceDownTo(position)
+	dl.ForceOptions(later...)
+}
+
+// Reset restores the structure to its freshly constructed state in
+// time linear in the number of entries, undoing any forced or disabled
+// options as well as the effects of abandoned searches.  Settings such
+// as costs, labels, and search limits are kept.
+func (dl *DLX) Reset() {
+	// Relink the primary items in order, and empty all columns.
+	lastItem := root
+	dl.uncovered = 0
+	for index := range dl.items {
+		item := &dl.items[index]
+		item.choices = 0
+		item.purifier = -1
+		dl.entries[item.head].up = int32(item.head)
+		dl.entries[item.head].down = int32(item.head)
+
+		if item.secondary {
+			continue
+		}
+		item.left = lastItem
+		dl.item(lastItem).right = index
+		lastItem = index
+		dl.uncovered++
 	}
+	dl.item(lastItem).right = root
+	dl.itemHead.left = lastItem
 
-	stages := []*stage{
-		&stage{
-			item:    item,
-			parent:  -1,
-			deleted: nil,
-			choices: choices,
-			i:       0,
-		},
+	// Append every entry to its column, in order.
+	for option := range dl.options {
+		for index := range dl.row(option) {
+			entry := &dl.entries[index]
+			head := dl.items[entry.item].head
+			entry.up = dl.entries[head].up
+			entry.down = int32(head)
+			dl.entries[entry.up].down = int32(index)
+			dl.entries[head].up = int32(index)
+			dl.items[entry.item].choices++
+		}
 	}
 
-	path := []Step{}
-	keepGoing := true
+	for i := range dl.hidden {
+		dl.hidden[i] = 0
+	}
 
-	for {
-		s := stages[len(stages)-1]
+	dl.selected = dl.selected[:0]
+	dl.disabled = dl.disabled[:0]
+	dl.buckets = nil
+	dl.starvedExact = false
+}
 
-		if s.i == len(s.choices) || !keepGoing {
-			stages = stages[:len(stages)-1]
+// Retracts forced options in reverse order until only the given
+// number of them remain.
+func (dl *DLX) unforceDownTo(count int) {
+	// Disabled options are deleted once retracted, which must happen
+	// before anything is forced.
+	for _, option := range dl.selected[count:] {
+		if intSliceContains(dl.disabled, option) {
+			kept := append([]int{}, dl.selected[:count]...)
+			dl.retract()
+			dl.reapply(kept)
+			return
+		}
+	}
 
-			if s.parent == -1 {
-				return keepGoing
-			}
+	for i := len(dl.selected) - 1; i >= count; i-- {
+		dl.unchooseOption(dl.selected[i])
+	}
+	dl.selected = dl.selected[:count]
+}
 
-			path = path[:len(path)-1]
-			dl.unchooseOption(s.parent, s.deleted)
+// GenerateSolutionsWith is like GenerateSolutions, but only yields
+// solutions including all the required options, as if they had been
+// forced.  Unlike with ForceOptions, the required options are only in
+// effect for the duration of the call.  As with forced options, the
+// required options are not part of the yielded solutions.
+func (dl *DLX) GenerateSolutionsWith(required []int, yield func([]Step) bool) bool {
+	defer dl.unforceDownTo(len(dl.selected))
+
+	for _, option := range required {
+		// Options already forced, or required more than once, are
+		// selected as they are.
+		if intSliceContains(dl.selected, option) {
 			continue
 		}
 
-		deleted := []int{}
-		dl.chooseOption(s.choices[s.i], &deleted)
-		path = append(path, Step{s.item, s.choices[s.i], s.choices})
+		// A required option that conflicts with other required or
+		// forced options rules out any solution.
+		if !dl.optionLive(option) {
+			return true
+		}
+		dl.ForceOptions(option)
+	}
+
+	return dl.GenerateSolutions(yield)
+}
+
+// GenerateCoversWith is like GenerateCovers, but only yields covers
+// including all the required options, which are not part of the
+// yielded covers themselves.
+func (dl *DLX) GenerateCoversWith(required []int, yield func([]int) bool) {
+	dl.GenerateSolutionsWith(required, func(solution []Step) bool {
+		return yield(coverOf(solution))
+	})
+}
+
+// Randomize makes the solver try choices, and break ties between
+// equally constrained items, in a random order determined by the
+// given seed.  This varies the order in which solutions are found,
+// reproducibly for a fixed seed.
+func (dl *DLX) Randomize(seed int64) {
+	dl.rng = rand.New(rand.NewSource(seed))
+	dl.tieRng = rand.New(rand.NewSource(seed))
+	dl.tieBreak = TieRandom
+	dl.buckets = nil
+}
+
+// Simplify repeatedly forces the only remaining option covering some
+// item, until no item is left with exactly one choice.  It reports
+// whether the problem turned out to be infeasible, i.e. some item can
+// no longer be covered at all.
+func (dl *DLX) Simplify() (infeasible bool) {
+	for {
+		item := dl.itemHead.right
+		for item != root && dl.items[item].choices > 1 {
+			item = dl.items[item].right
+		}
+
+		switch {
+		case item == root:
+			return false
+		case dl.items[item].choices == 0:
+			return true
+		}
+
+		head := dl.items[item].head
+		dl.ForceOptions(int(dl.entries[dl.entries[head].down].option))
+	}
+}
 
-		item, choices := dl.nextChoices()
+// Deduce is like Simplify, but also returns the options it forced, in
+// order.  Deduced options can be retracted like any other forced
+// options.
+func (dl *DLX) Deduce() (implied []int, infeasible bool) {
+	count := len(dl.selected)
+	infeasible = dl.Simplify()
+	return append([]int{}, dl.selected[count:]...), infeasible
+}
 
-		if choices == nil {
-			keepGoing = yield(append([]Step{}, path...))
+// UncoverableItems returns the items that still need to be covered
+// but that no remaining option covers.
+func (dl *DLX) UncoverableItems() []int {
+	items := []int{}
+	for item := dl.itemHead.right; item != root; item = dl.items[item].right {
+		if dl.items[item].choices == 0 {
+			items = append(items, item)
 		}
+	}
+	return items
+}
 
-		// Consider each option that covers the first item.
-		stages = append(stages, &stage{
-			item:    item,
-			parent:  s.choices[s.i],
-			deleted: deleted,
-			choices: choices,
-			i:       0,
-		})
+// Err returns the reason the most recent search did not explore the
+// whole search space, e.g. an *UncoverableError if it failed fast or
+// ErrNodeLimit if it ran out of budget, the context's error if its
+// context was done, or nil if the search ran normally (even if stopped
+// early by its caller).
+func (dl *DLX) Err() error {
+	return dl.err
+}
 
-		s.i++
+// Fails fast, recording an *UncoverableError, if some item cannot be
+// covered at all.
+func (dl *DLX) checkCoverable() bool {
+	dl.resetSearch()
+	if items := dl.UncoverableItems(); len(items) > 0 {
+		dl.err = &UncoverableError{items}
+		return false
 	}
+	dl.starved = 0
+	dl.starvedExact = true
+	return true
 }
 
-func (dl *DLX) GenerateCovers(yield func([]int) bool) {
-	dl.GenerateSolutions(func(solution []Step) bool {
-		cover := make([]int, len(solution))
-		for i, step := range solution {
-			cover[i] = step.Option
+func (dl *DLX) GenerateSolutions(yield func([]Step) bool) bool {
+	search := dl.NewSearch()
+	for {
+		solution, ok := search.Next()
+		if !ok {
+			return dl.searchErr() == nil
 		}
-		return yield(cover)
+
+		if !yield(solution) {
+			search.Close()
+			return false
+		}
+	}
+}
+
+// GenerateCovers yields the options of each solution.  Only the cover
+// itself is allocated for each solution, which the caller may keep.
+func (dl *DLX) GenerateCovers(yield func([]int) bool) {
+	copied := func(cover []int) bool {
+		return yield(append([]int{}, cover...))
+	}
+	if dl.searchCovers(copied) {
+		return
+	}
+
+	dl.GenerateSolutionsNoCopy(func(solution []Step) bool {
+		return yield(coverOf(solution))
 	})
 }
 
+// The options selected along a solution path.
+func coverOf(solution []Step) []int {
+	cover := make([]int, len(solution))
+	for i, step := range solution {
+		cover[i] = step.Option
+	}
+	return cover
+}
+
 func (dl *DLX) AllSolutions() [][]Step {
 	solutions := make([][]Step, 0)
 	dl.GenerateSolutions(func(solution []Step) bool {
@@ -291,6 +812,38 @@ func (dl *DLX) AllCovers() [][]int {
 	return covers
 }
 
+// AllSolutionsN is like AllSolutions, but returns at most limit
+// solutions, and reports whether it left out any.  To tell, it
+// searches for one solution past the limit.
+func (dl *DLX) AllSolutionsN(limit int) (solutions [][]Step, truncated bool) {
+	solutions = make([][]Step, 0)
+	dl.GenerateSolutions(func(solution []Step) bool {
+		if len(solutions) >= limit {
+			truncated = true
+			return false
+		}
+		solutions = append(solutions, solution)
+		return true
+	})
+	return solutions, truncated
+}
+
+// AllCoversN is like AllCovers, but returns at most limit covers, and
+// reports whether it left out any.  To tell, it searches for one
+// cover past the limit.
+func (dl *DLX) AllCoversN(limit int) (covers [][]int, truncated bool) {
+	covers = make([][]int, 0)
+	dl.GenerateCovers(func(cover []int) bool {
+		if len(covers) >= limit {
+			truncated = true
+			return false
+		}
+		covers = append(covers, cover)
+		return true
+	})
+	return covers, truncated
+}
+
 func (dl *DLX) AnySolution() []Step {
 	var solution []Step
 	dl.GenerateSolutions(func(s []Step) bool {
@@ -309,99 +862,450 @@ func (dl *DLX) AnyCover() []int {
 	return cover
 }
 
-func (dl *DLX) chooseOption(index int, deleted *[]int) {
-	// Keep track of deleted options so that (1) we don't do redundant
-	// deletes, which break things, and (2) we can un-delete them in
-	// reverse order.  The slice stores indices of deleted options in
-	// the order they are deleted.
-
-	// Delete each covered item.
-	for _, covered := range dl.options[index] {
-		item := covered.item
-
-		// Delete covered item from linked list.
-		item.left.right = item.right
-		item.right.left = item.left
-
-		// Delete all options that cover the same item, since we can
-		// only cover each item once.
-		for conflict := item.head.down; conflict != item.head; conflict = conflict.down {
-			// We can only delete nodes once; trying to re-delete may
-			// break things.  So if we've already deleted something, don't
-			// try delete it again.
-			if intSliceContains(*deleted, conflict.option) {
-				continue
-			}
+// TryAnySolution is like AnySolution, but also reports whether it
+// found a solution, which tells an empty solution apart from none at
+// all.
+func (dl *DLX) TryAnySolution() (solution []Step, ok bool) {
+	dl.GenerateSolutions(func(s []Step) bool {
+		solution, ok = s, true
+		return false
+	})
+	return solution, ok
+}
+
+// TryAnyCover is like AnyCover, but also reports whether it found a
+// cover, which tells an empty cover apart from none at all.
+func (dl *DLX) TryAnyCover() (cover []int, ok bool) {
+	dl.GenerateCovers(func(c []int) bool {
+		cover, ok = c, true
+		return false
+	})
+	return cover, ok
+}
+
+// UniqueSolution returns the first solution found, or nil if there
+// is none, and whether it is the only one.  The search stops as soon
+// as it finds a second solution.
+func (dl *DLX) UniqueSolution() (solution []Step, unique bool) {
+	count := 0
+	dl.GenerateSolutions(func(s []Step) bool {
+		if count == 0 {
+			solution = s
+		}
+		count++
+		return count < 2
+	})
+	return solution, count == 1
+}
+
+// HasSolution reports whether there is any solution at all, stopping
+// as soon as it finds one.  Like CountSolutions, it walks the linked
+// structure without recording solutions, so it is the cheapest way to
+// check feasibility.
+func (dl *DLX) HasSolution() bool {
+	_, found := dl.CountSolutionsUpTo(1)
+	return found
+}
 
-			// Record deleted option.
-			*deleted = append(*deleted, conflict.option)
+// HasUniqueSolution reports whether there is exactly one solution,
+// stopping as soon as it finds a second one.
+func (dl *DLX) HasUniqueSolution() bool {
+	count, _ := dl.CountSolutionsUpTo(2)
+	return count == 1
+}
 
-			// To delete an option, we go through and delete each entry in
-			// the option.
-			for _, entry := range dl.options[conflict.option] {
-				entry.up.down = entry.down
-				entry.down.up = entry.up
+// Applies a move, either selecting an option or excluding one.
+func (dl *DLX) move(move int) {
+	if move >= 0 {
+		dl.chooseOption(move)
+		return
+	}
+	dl.deleteOption(^move)
+}
 
-				// Update the corresponding item's record of remaining
-				// items.
-				entry.item.choices--
-			}
+func (dl *DLX) unmove(move int) {
+	if move >= 0 {
+		dl.unchooseOption(move)
+		return
+	}
+	dl.restoreOption(^move)
+}
+
+// The option selected or excluded by a move.
+func optionOfMove(move int) int {
+	if move >= 0 {
+		return move
+	}
+	return ^move
+}
+
+// Selects an option, covering each of its items in turn: an uncolored
+// item is covered outright, while a colored one is purified, ruling
+// out only the options that disagree on its color.
+func (dl *DLX) chooseOption(index int) {
+	first := int(dl.options[index])
+	if first == -1 {
+		return
+	}
+	if dl.conflicts != nil {
+		dl.chooseCompiled(index)
+		return
+	}
+	for entry := first; ; {
+		covered := &dl.entries[entry]
+		switch {
+		case covered.color == 0:
+			dl.cover(int(covered.item))
+		case dl.items[covered.item].purifier == -1:
+			dl.purify(entry)
+		}
+		if entry = int(covered.right); entry == first {
+			break
 		}
 	}
 }
 
-func (dl *DLX) unchooseOption(index int, deleted []int) {
-	// Uncover items in reverse order.
-	entries := dl.options[index]
-	for i := range entries {
-		// We deleted the items left to right (increasing index), so we
-		// uncover the items right to left (decreasing index).
-		entry := entries[len(entries)-1-i]
-		item := entry.item
+func (dl *DLX) unchooseOption(index int) {
+	first := int(dl.options[index])
+	if first == -1 {
+		return
+	}
+	if dl.conflicts != nil {
+		dl.unchooseCompiled(index)
+		return
+	}
 
-		// Uncover item.
-		item.left.right = item
-		item.right.left = item
+	// Undo the covers in reverse order.
+	last := int(dl.entries[first].left)
+	for entry := last; ; {
+		covered := &dl.entries[entry]
+		switch {
+		case covered.color == 0:
+			dl.uncover(int(covered.item))
+		case dl.items[covered.item].purifier == entry:
+			dl.unpurify(entry)
+		}
+		if entry = int(covered.left); entry == last {
+			break
+		}
 	}
+}
 
-	dl.restoreOptions(deleted)
+// Removes an item from the items left to cover, and hides every option
+// covering it from the other items' columns.  The item's own column is
+// left intact, so that uncover can walk it again.  Secondary items are
+// not in the list to begin with, and point only to themselves.
+func (dl *DLX) cover(index int) {
+	dl.unlinkItem(index)
+	head := dl.items[index].head
+	for e := int(dl.entries[head].down); e != head; e = int(dl.entries[e].down) {
+		dl.hide(e)
+	}
 }
 
-func (dl *DLX) restoreOptions(options []int) {
-	// Restore conflicting options in reverse order.
-	for i := range options {
-		// To restore the option, we restore each entry in the option.
-		for _, entry := range dl.options[options[len(options)-1-i]] {
-			entry.up.down = entry
-			entry.down.up = entry
+func (dl *DLX) uncover(index int) {
+	head := dl.items[index].head
+	for e := int(dl.entries[head].up); e != head; e = int(dl.entries[e].up) {
+		dl.unhide(e)
+	}
+	dl.relinkItem(index)
+}
 
-			// Update item's choices counter.
-			entry.item.choices++
+// Removes an item from the items left to cover, leaving its column
+// alone.
+func (dl *DLX) unlinkItem(index int) {
+	item := &dl.items[index]
+	dl.item(item.left).right = item.right
+	dl.item(item.right).left = item.left
+	if dl.buckets != nil {
+		dl.buckets.remove(index)
+	}
+	if !item.secondary {
+		dl.uncovered--
+		if item.choices == 0 {
+			dl.starved--
 		}
 	}
 }
 
-func (dl *DLX) nextChoices() (int, []int) {
-	// First item to cover.  We find the item with the fewest remaining
-	// choices.
-	first := dl.itemHead.right
-	for item := first; item != dl.itemHead; item = item.right {
-		if item.choices < first.choices {
-			first = item
+func (dl *DLX) relinkItem(index int) {
+	item := &dl.items[index]
+	dl.item(item.left).right = index
+	dl.item(item.right).left = index
+	if dl.buckets != nil {
+		dl.buckets.restore(index)
+	}
+	if !item.secondary {
+		dl.uncovered++
+		if item.choices == 0 {
+			dl.starved++
+		}
+	}
+}
+
+// Rules out the options disagreeing with the color an entry assigns to
+// its item.  The options agreeing with it stay in the item's column.
+func (dl *DLX) purify(index int) {
+	purifier := &dl.entries[index]
+	item := &dl.items[purifier.item]
+	item.purifier = index
+
+	for e := int(dl.entries[item.head].down); e != item.head; e = int(dl.entries[e].down) {
+		if dl.entries[e].color != purifier.color {
+			dl.hide(e)
+		}
+	}
+}
+
+func (dl *DLX) unpurify(index int) {
+	purifier := &dl.entries[index]
+	item := &dl.items[purifier.item]
+
+	for e := int(dl.entries[item.head].up); e != item.head; e = int(dl.entries[e].up) {
+		if dl.entries[e].color != purifier.color {
+			dl.unhide(e)
+		}
+	}
+	item.purifier = -1
+}
+
+// Hides the option of an entry from the columns of its other entries,
+// except those of items purified to a color the option agrees with.
+func (dl *DLX) hide(index int) {
+	dl.hidden[dl.entries[index].option]++
+	for e := int(dl.entries[index].right); e != index; e = int(dl.entries[e].right) {
+		if !dl.agrees(e) {
+			dl.unlinkEntry(e)
+		}
+	}
+}
+
+func (dl *DLX) unhide(index int) {
+	for e := int(dl.entries[index].left); e != index; e = int(dl.entries[e].left) {
+		if !dl.agrees(e) {
+			dl.relinkEntry(e)
+		}
+	}
+	dl.hidden[dl.entries[index].option]--
+}
+
+// Whether an entry's item has been purified to the entry's color.
+func (dl *DLX) agrees(index int) bool {
+	// Only colored items are purified, so an uncolored entry never
+	// agrees, which spares looking up its item.
+	entry := &dl.entries[index]
+	if entry.color == 0 {
+		return false
+	}
+	purifier := dl.items[entry.item].purifier
+	return purifier != -1 && dl.entries[purifier].color == entry.color
+}
+
+// Removes an option from the columns of all its items, excluding it
+// from the search.
+func (dl *DLX) deleteOption(index int) {
+	dl.hidden[index]++
+	for e := range dl.row(index) {
+		dl.unlinkEntry(e)
+	}
+}
+
+func (dl *DLX) restoreOption(index int) {
+	first := int(dl.options[index])
+	if first != -1 {
+		last := int(dl.entries[first].left)
+		for e := last; ; e = int(dl.entries[e].left) {
+			dl.relinkEntry(e)
+			if e == first {
+				break
+			}
+		}
+	}
+	dl.hidden[index]--
+}
+
+// Unlinks an entry from its column, updating the item's record of
+// remaining choices.
+func (dl *DLX) unlinkEntry(index int) {
+	entry := &dl.entries[index]
+	dl.entries[entry.up].down = entry.down
+	dl.entries[entry.down].up = entry.up
+
+	item := &dl.items[entry.item]
+	item.choices--
+	dl.clock++
+	item.touched = dl.clock
+	if dl.buckets != nil {
+		dl.buckets.update(int(entry.item))
+	}
+	if item.choices == 0 && dl.remaining(int(entry.item)) {
+		dl.starved++
+	}
+}
+
+func (dl *DLX) relinkEntry(index int) {
+	entry := &dl.entries[index]
+	dl.entries[entry.up].down = int32(index)
+	dl.entries[entry.down].up = int32(index)
+
+	item := &dl.items[entry.item]
+	item.choices++
+	dl.clock++
+	item.touched = dl.clock
+	if dl.buckets != nil {
+		dl.buckets.update(int(entry.item))
+	}
+	if item.choices == 1 && dl.remaining(int(entry.item)) {
+		dl.starved--
+	}
+}
+
+// Whether an item is a primary item left to cover.  A covered item's
+// neighbors no longer link to it.
+func (dl *DLX) remaining(index int) bool {
+	item := &dl.items[index]
+	return !item.secondary && dl.item(item.left).right == index
+}
+
+// Whether some item left to cover has no choices left, so that the
+// search can back out right away rather than branch on it.
+func (dl *DLX) deadEnd() bool {
+	if dl.starved > 0 {
+		dl.deadEnds++
+		return true
+	}
+	return false
+}
+
+// The entries of an option, in order, following the row links.
+func (dl *DLX) row(option int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		first := int(dl.options[option])
+		if first == -1 {
+			return
+		}
+		for e := first; ; {
+			if !yield(e) {
+				return
+			}
+			if e = int(dl.entries[e].right); e == first {
+				return
+			}
 		}
 	}
+}
+
+// The number of entries of an option.
+func (dl *DLX) optionSize(option int) int {
+	size := 0
+	for range dl.row(option) {
+		size++
+	}
+	return size
+}
+
+// Whether the option is still available, i.e. neither selected nor
+// ruled out.  Options without any entries are never available.
+func (dl *DLX) optionLive(index int) bool {
+	return dl.options[index] != -1 && dl.hidden[index] == 0
+}
+
+// The next item to cover and the options covering it, appended to the
+// given buffer, or -1 and nil if there is nothing left to cover.
+func (dl *DLX) nextChoices(buf []int) (int, []int) {
+	first := dl.nextItem()
 
 	// Nothing left to cover!
-	if first == dl.itemHead {
+	if first == root {
 		return -1, nil
 	}
 
-	choices := []int{}
-	for choice := first.head.down; choice != first.head; choice = choice.down {
-		choices = append(choices, choice.option)
+	choices := buf[:0]
+	head := dl.items[first].head
+	for choice := int(dl.entries[head].down); choice != head; choice = int(dl.entries[choice].down) {
+		choices = append(choices, int(dl.entries[choice].option))
+	}
+
+	if dl.rng != nil {
+		dl.rng.Shuffle(len(choices), func(i, j int) {
+			choices[i], choices[j] = choices[j], choices[i]
+		})
+	}
+
+	return first, choices
+}
+
+// The next item to cover, namely the item with the fewest remaining
+// choices, with ties broken according to the tie-breaking policy, or
+// the first one in a static order, or root if there are no items left
+// to cover.
+func (dl *DLX) nextItem() int {
+	if dl.order == OrderStatic {
+		return dl.staticItem()
+	}
+
+	if dl.buckets == nil {
+		dl.buckets = dl.newItemBuckets()
+	}
+	if dl.buckets != nil {
+		return dl.buckets.first()
+	}
+
+	first := dl.itemHead.right
+	if first == root {
+		return root
+	}
+
+	// With ties going to the lowest index, the first item found with
+	// no choices can't be beaten, and neither can one with a single
+	// choice, unless some item has none, which the search catches as
+	// a dead end before it gets here.  Outside a search, the count of
+	// such items may be off.
+	unbeatable := -1
+	if dl.tieBreak == TieLowestIndex && dl.itemRank == nil {
+		unbeatable = 0
+		if dl.starvedExact && dl.starved == 0 {
+			unbeatable = 1
+		}
 	}
 
-	return first.index, choices
+	ties := 1
+	for index := dl.items[first].right; index != root && dl.items[first].choices > unbeatable; index = dl.items[index].right {
+		item, best := &dl.items[index], &dl.items[first]
+		switch {
+		case item.choices < best.choices:
+			first = index
+			ties = 1
+		case item.choices > best.choices:
+		case dl.tieBreak == TieLastTouched:
+			if item.touched > best.touched || item.touched == best.touched && dl.itemBefore(index, first) {
+				first = index
+			}
+		case dl.tieBreak == TieLowestIndex:
+			if dl.itemBefore(index, first) {
+				first = index
+			}
+		case dl.tieBreak == TieRandom:
+			// Pick uniformly among tied items, replacing the current
+			// pick with probability 1/ties.
+			ties++
+			if dl.random().Intn(ties) == 0 {
+				first = index
+			}
+		case dl.tieBreak == TieFunc:
+			ties++
+		}
+	}
+
+	if dl.tieBreak == TieFunc && ties > 1 {
+		return dl.tieFunc(dl.tiedItems(dl.items[first].choices))
+	}
+	return first
+}
+
+// The number of primary items left to cover.
+func (dl *DLX) uncoveredCount() int {
+	return dl.uncovered
 }
 
 func intSliceContains(slice []int, element int) bool {
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"unsafe"
 )
 
 type example struct {
@@ -36,9 +37,9 @@ var (
 		},
 		solution: [][]Step{
 			[]Step{
-				Step{0, 3, []int{1, 3}},
-				Step{1, 4, []int{4}},
-				Step{2, 0, []int{0}},
+				Step{0, 3, []int{1, 3}, 0, 7, "", ""},
+				Step{1, 4, []int{4}, 1, 4, "", ""},
+				Step{2, 0, []int{0}, 2, 2, "", ""},
 			},
 		},
 	}
@@ -67,24 +68,24 @@ var (
 		},
 		solution: [][]Step{
 			[]Step{
-				Step{1, 6, []int{3, 6}},
-				Step{0, 4, []int{4, 5}},
-				Step{2, 0, []int{0, 1}},
+				Step{1, 6, []int{3, 6}, 0, 7, "", ""},
+				Step{0, 4, []int{4, 5}, 1, 5, "", ""},
+				Step{2, 0, []int{0, 1}, 2, 2, "", ""},
 			},
 			[]Step{
-				Step{1, 6, []int{3, 6}},
-				Step{0, 4, []int{4, 5}},
-				Step{2, 1, []int{0, 1}},
+				Step{1, 6, []int{3, 6}, 0, 7, "", ""},
+				Step{0, 4, []int{4, 5}, 1, 5, "", ""},
+				Step{2, 1, []int{0, 1}, 2, 2, "", ""},
 			},
 			[]Step{
-				Step{1, 6, []int{3, 6}},
-				Step{0, 5, []int{4, 5}},
-				Step{2, 0, []int{0, 1}},
+				Step{1, 6, []int{3, 6}, 0, 7, "", ""},
+				Step{0, 5, []int{4, 5}, 1, 5, "", ""},
+				Step{2, 0, []int{0, 1}, 2, 2, "", ""},
 			},
 			[]Step{
-				Step{1, 6, []int{3, 6}},
-				Step{0, 5, []int{4, 5}},
-				Step{2, 1, []int{0, 1}},
+				Step{1, 6, []int{3, 6}, 0, 7, "", ""},
+				Step{0, 5, []int{4, 5}, 1, 5, "", ""},
+				Step{2, 1, []int{0, 1}, 2, 2, "", ""},
 			},
 		},
 	}
@@ -150,6 +151,32 @@ func TestToMatrix(t *testing.T) {
 	testToMatrix(t, trivial)
 }
 
+func TestFromSparse(t *testing.T) {
+	for _, e := range []example{classic, classicDuplicates, impossible, trivial} {
+		dl := FromSparse(e.options)
+		if mat := dl.ToMatrix(); !reflect.DeepEqual(mat, e.matrix) {
+			t.Errorf(
+				"matrix mismatch:\nshould be\n%s\ngot\n%s",
+				sprintMatrix(e.matrix), sprintMatrix(mat),
+			)
+		}
+		testExample(t, dl.AllSolutions(), e.solution)
+	}
+}
+
+func TestToOptions(t *testing.T) {
+	dl := New(3, [][]int{[]int{2, 0}, []int{}, []int{1}})
+	dl.ForceOptions(2)
+	options := dl.ToOptions()
+	if !reflect.DeepEqual(options, [][]int{[]int{2, 0}, []int{}, []int{1}}) {
+		t.Errorf("should be [[2 0] [] [1]], got %v", options)
+	}
+
+	for _, e := range []example{classic, classicDuplicates, impossible, trivial} {
+		testExample(t, New(e.itemCount, e.toDLX().ToOptions()).AllSolutions(), e.solution)
+	}
+}
+
 func sortSequences(sequences [][]int) {
 	// First, sort each individual cover.
 	for _, seq := range sequences {
@@ -215,6 +242,65 @@ func BenchmarkExamples(b *testing.B) {
 	}
 }
 
+// Options of an n-by-n latin square: each cell holds one value, and
+// each value appears once in each row and each column.
+func latinSquare(n int) (int, [][]int) {
+	options := [][]int{}
+	for row := 0; row < n; row++ {
+		for column := 0; column < n; column++ {
+			for value := 0; value < n; value++ {
+				options = append(options, []int{
+					n*row + column,
+					n*n + n*row + value,
+					2*n*n + n*column + value,
+				})
+			}
+		}
+	}
+	return 3 * n * n, options
+}
+
+func TestNewAllocations(t *testing.T) {
+	itemCount, options := latinSquare(25)
+
+	// The nodes live in a handful of flat slices, so construction
+	// should not allocate per node.
+	if allocs := testing.AllocsPerRun(10, func() { New(itemCount, options) }); allocs > 10 {
+		t.Errorf("construction should barely allocate, allocated %v times", allocs)
+	}
+
+	colored := make([][]ColoredItem, len(options))
+	matrix := make([][]bool, len(options))
+	for i, option := range options {
+		matrix[i] = make([]bool, itemCount)
+		for _, item := range option {
+			colored[i] = append(colored[i], ColoredItem{Item: item})
+			matrix[i][item] = true
+		}
+	}
+	if allocs := testing.AllocsPerRun(10, func() { NewColored(itemCount, 0, colored) }); allocs > 10 {
+		t.Errorf("colored construction should barely allocate, allocated %v times", allocs)
+	}
+	if allocs := testing.AllocsPerRun(10, func() { FromMatrix(matrix) }); allocs > 10 {
+		t.Errorf("construction from a matrix should barely allocate, allocated %v times", allocs)
+	}
+}
+
+func TestEntrySize(t *testing.T) {
+	// Links stored as 32-bit indices keep the nodes small, even with
+	// links along the rows.
+	if size := unsafe.Sizeof(entryNode{}); size > 28 {
+		t.Errorf("entry nodes should take at most 28 bytes, take %d", size)
+	}
+}
+
+func BenchmarkLatinSquare(b *testing.B) {
+	itemCount, options := latinSquare(25)
+	for i := 0; i < b.N; i++ {
+		New(itemCount, options).AnyCover()
+	}
+}
+
 func TestYieldBreak(t *testing.T) {
 	count := 0
 	dl := classicDuplicates.toDLX()
@@ -236,12 +322,12 @@ func TestForceOptions(t *testing.T) {
 	dl.ForceOptions(0)
 	testExample(t, dl.AllSolutions(), [][]Step{
 		[]Step{
-			Step{1, 6, []int{6}},
-			Step{0, 4, []int{4, 5}},
+			Step{1, 6, []int{6}, 0, 5, "", ""},
+			Step{0, 4, []int{4, 5}, 1, 3, "", ""},
 		},
 		[]Step{
-			Step{1, 6, []int{6}},
-			Step{0, 5, []int{4, 5}},
+			Step{1, 6, []int{6}, 0, 5, "", ""},
+			Step{0, 5, []int{4, 5}, 1, 3, "", ""},
 		},
 	})
 
@@ -249,12 +335,12 @@ func TestForceOptions(t *testing.T) {
 	dl.ForceOptions(0, 1)
 	testExample(t, dl.AllSolutions(), [][]Step{
 		[]Step{
-			Step{1, 6, []int{6}},
-			Step{0, 4, []int{4, 5}},
+			Step{1, 6, []int{6}, 0, 5, "", ""},
+			Step{0, 4, []int{4, 5}, 1, 3, "", ""},
 		},
 		[]Step{
-			Step{1, 6, []int{6}},
-			Step{0, 5, []int{4, 5}},
+			Step{1, 6, []int{6}, 0, 5, "", ""},
+			Step{0, 5, []int{4, 5}, 1, 3, "", ""},
 		},
 	})
 
@@ -262,12 +348,12 @@ func TestForceOptions(t *testing.T) {
 	dl.ForceOptions(4)
 	testExample(t, dl.AllSolutions(), [][]Step{
 		[]Step{
-			Step{1, 6, []int{6}},
-			Step{2, 0, []int{0, 1}},
+			Step{1, 6, []int{6}, 0, 4, "", ""},
+			Step{2, 0, []int{0, 1}, 1, 2, "", ""},
 		},
 		[]Step{
-			Step{1, 6, []int{6}},
-			Step{2, 1, []int{0, 1}},
+			Step{1, 6, []int{6}, 0, 4, "", ""},
+			Step{2, 1, []int{0, 1}, 1, 2, "", ""},
 		},
 	})
 
@@ -275,3 +361,343 @@ func TestForceOptions(t *testing.T) {
 	dl.ForceOptions(2)
 	testExample(t, dl.AllSolutions(), [][]Step{})
 }
+
+func TestColored(t *testing.T) {
+	// Knuth's XCC example from TAOCP 7.2.2.1: primary items p, q, r;
+	// secondary items x, y.
+	const A, B = 1, 2
+	dl := NewColored(3, 2, [][]ColoredItem{
+		[]ColoredItem{{0, 0}, {1, 0}, {3, 0}, {4, A}},
+		[]ColoredItem{{0, 0}, {2, 0}, {3, A}, {4, 0}},
+		[]ColoredItem{{0, 0}, {3, B}},
+		[]ColoredItem{{1, 0}, {3, A}},
+		[]ColoredItem{{2, 0}, {4, B}},
+	})
+	testExample(t, dl.AllSolutions(), [][]Step{
+		[]Step{
+			Step{1, 3, []int{0, 3}, 0, 3, "", ""},
+			Step{0, 1, []int{1}, 1, 2, "", ""},
+		},
+	})
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("should panic on a color too large to store")
+		}
+	}()
+	NewColored(1, 1, [][]ColoredItem{{{0, 0}, {1, 1 << 40}}})
+}
+
+func TestSimplify(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	dl.ForceOptions(0)
+	if dl.Simplify() {
+		t.Errorf("should remain feasible")
+	}
+	if !reflect.DeepEqual(dl.selected, []int{0, 6}) {
+		t.Errorf("should force [0 6], forced %v", dl.selected)
+	}
+	testExample(t, dl.AllSolutions(), [][]Step{
+		[]Step{Step{0, 4, []int{4, 5}, 0, 3, "", ""}},
+		[]Step{Step{0, 5, []int{4, 5}, 0, 3, "", ""}},
+	})
+
+	if !impossible.toDLX().Simplify() {
+		t.Errorf("impossible example should be infeasible")
+	}
+}
+
+func TestDeduce(t *testing.T) {
+	dl := classic.toDLX()
+	dl.ForceOptions(3)
+	implied, infeasible := dl.Deduce()
+	if infeasible || !reflect.DeepEqual(implied, []int{4, 0}) {
+		t.Errorf("should deduce [4 0], got %v (infeasible %v)", implied, infeasible)
+	}
+	testExample(t, dl.AllSolutions(), [][]Step{[]Step{}})
+
+	dl = classic.toDLX()
+	dl.ForceOptions(1)
+	if implied, infeasible := dl.Deduce(); !infeasible || !reflect.DeepEqual(implied, []int{2}) {
+		t.Errorf("should deduce [2] and infeasibility, got %v (infeasible %v)", implied, infeasible)
+	}
+}
+
+func TestUncoverable(t *testing.T) {
+	dl := New(3, [][]int{[]int{0}, []int{0, 2}})
+	testExample(t, dl.AllSolutions(), [][]Step{})
+
+	err, ok := dl.Err().(*UncoverableError)
+	if !ok || !reflect.DeepEqual(err.Items, []int{1}) {
+		t.Errorf("should report item 1 as uncoverable, got %v", dl.Err())
+	}
+
+	dl = classic.toDLX()
+	dl.AllSolutions()
+	if dl.Err() != nil {
+		t.Errorf("classic example should have no error, got %v", dl.Err())
+	}
+}
+
+func TestSymmetry(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	dl.AddSymmetry(0, 1)
+	dl.AddSymmetry(4, 5)
+	testExample(t, dl.AllSolutions(), classicDuplicates.solution[:1])
+}
+
+func TestCollapseDuplicates(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	dl.CollapseDuplicates()
+	testExample(t, dl.AllSolutions(), classicDuplicates.solution[:1])
+
+	// Options covering the same items in a different order are
+	// identical too.
+	dl = New(2, [][]int{[]int{0, 1}, []int{0}, []int{1, 0}, []int{1}})
+	dl.CollapseDuplicates()
+	covers := dl.AllCovers()
+	sortSequences(covers)
+	if !reflect.DeepEqual(covers, [][]int{[]int{0}, []int{1, 3}}) {
+		t.Errorf("should be [[0] [1 3]], got %v", covers)
+	}
+}
+
+func coverSet(solutions [][]Step) [][]int {
+	covers := make([][]int, len(solutions))
+	for i, solution := range solutions {
+		covers[i] = make([]int, len(solution))
+		for j, step := range solution {
+			covers[i][j] = step.Option
+		}
+	}
+	sortSequences(covers)
+	return covers
+}
+
+func TestRandomize(t *testing.T) {
+	expected := coverSet(classicDuplicates.solution)
+	for seed := int64(0); seed < 10; seed++ {
+		dl := classicDuplicates.toDLX()
+		dl.Randomize(seed)
+		solutions := dl.AllSolutions()
+		if covers := coverSet(solutions); !reflect.DeepEqual(covers, expected) {
+			t.Errorf("seed %d: should find covers %v, found %v", seed, expected, covers)
+		}
+
+		dl.Randomize(seed)
+		testExample(t, dl.AllSolutions(), solutions)
+	}
+}
+
+func TestTieBreak(t *testing.T) {
+	// Every item of the classic example except 3 and 6 starts out with
+	// 2 choices.
+	dl := classic.toDLX()
+	calls := 0
+	dl.SetTieBreakFunc(func(items []int) int {
+		calls++
+		if calls == 1 && !reflect.DeepEqual(items, []int{0, 1, 2, 4, 5}) {
+			t.Errorf("should be tied between [0 1 2 4 5], got %v", items)
+		}
+		return items[len(items)-1]
+	})
+	if solution := dl.AnySolution(); solution[0].Item != 5 {
+		t.Errorf("should first cover item 5, got %v", solution)
+	}
+
+	for _, policy := range []TieBreak{TieLowestIndex, TieLastTouched, TieRandom} {
+		dl := classicDuplicates.toDLX()
+		dl.SetTieBreak(policy)
+		if covers := coverSet(dl.AllSolutions()); !reflect.DeepEqual(covers, coverSet(classicDuplicates.solution)) {
+			t.Errorf("policy %d: wrong covers %v", policy, covers)
+		}
+	}
+
+	// Breaking ties at random leaves the choices in order.
+	dl = New(2, [][]int{{0, 1}, {0, 1}, {0, 1}, {0, 1}, {0, 1}, {0, 1}})
+	dl.SetTieBreak(TieRandom)
+	for _, solution := range dl.AllSolutions() {
+		for _, step := range solution {
+			if !sort.IntsAreSorted(step.Choices) {
+				t.Errorf("should not shuffle choices, got %v", step.Choices)
+			}
+		}
+	}
+}
+
+func TestOrderLexicographic(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	dl.SetOrder(OrderLexicographic)
+	covers := dl.AllCovers()
+	expected := [][]int{{0, 4, 6}, {0, 5, 6}, {1, 4, 6}, {1, 5, 6}}
+	if !reflect.DeepEqual(covers, expected) {
+		t.Errorf("should be %v, got %v", expected, covers)
+	}
+
+	testExample(t, dl.AllSolutions()[:1], [][]Step{
+		[]Step{
+			Step{2, 0, []int{0, 1, 3}, 0, 7, "", ""},
+			Step{0, 4, []int{4, 5}, 1, 5, "", ""},
+			Step{1, 6, []int{6}, 2, 2, "", ""},
+		},
+	})
+}
+
+func TestOrderReverseLexicographic(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	dl.SetOrder(OrderReverseLexicographic)
+	covers := dl.AllCovers()
+	expected := [][]int{{1, 5, 6}, {1, 4, 6}, {0, 5, 6}, {0, 4, 6}}
+	if !reflect.DeepEqual(covers, expected) {
+		t.Errorf("should be %v, got %v", expected, covers)
+	}
+}
+
+func TestOrderStatic(t *testing.T) {
+	itemCount, options := latinSquare(4)
+	reversed := make([]int, itemCount)
+	for i := range reversed {
+		reversed[i] = itemCount - 1 - i
+	}
+
+	expected := coverSet(New(itemCount, options).AllSolutions())
+	for _, order := range [][]int{nil, reversed} {
+		dl := New(itemCount, options, WithOrder(OrderStatic), WithItemOrder(order))
+		solutions := dl.AllSolutions()
+		if covers := coverSet(solutions); !reflect.DeepEqual(covers, expected) {
+			t.Errorf("should be %v, got %v", expected, covers)
+		}
+		if count := dl.CountSolutions().Int64(); count != int64(len(expected)) {
+			t.Errorf("should count %d solutions, counted %d", len(expected), count)
+		}
+
+		// Each step covers the first item left, in the order given.
+		for _, solution := range solutions {
+			for i := 1; i < len(solution); i++ {
+				if !dl.itemBefore(solution[i-1].Item, solution[i].Item) {
+					t.Errorf("solution %v should branch on the items in order", solution)
+				}
+			}
+		}
+	}
+}
+
+func TestUniqueSolution(t *testing.T) {
+	solution, unique := classic.toDLX().UniqueSolution()
+	if !unique {
+		t.Errorf("classic example should have a unique solution")
+	}
+	testExample(t, [][]Step{solution}, classic.solution)
+
+	if classicDuplicates.toDLX().HasUniqueSolution() {
+		t.Errorf("classicDuplicates example should have several solutions")
+	}
+	if impossible.toDLX().HasUniqueSolution() {
+		t.Errorf("impossible example should have no solution")
+	}
+}
+
+func TestHasSolution(t *testing.T) {
+	for _, e := range []example{classic, classicDuplicates, impossible, trivial} {
+		if has, expected := e.toDLX().HasSolution(), len(e.solution) > 0; has != expected {
+			t.Errorf("%v: should report %v, reported %v", e.options, expected, has)
+		}
+	}
+
+	// Too many items for bitmasks, so the search runs on the links.
+	itemCount, options := latinSquare(6)
+	dl := New(itemCount, options)
+	if allocs := testing.AllocsPerRun(10, func() { dl.HasSolution() }); allocs > 0 {
+		t.Errorf("feasibility check should not allocate, allocated %v times", allocs)
+	}
+}
+
+func TestGenerateSolutionsWith(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	solutions := [][]Step{}
+	dl.GenerateSolutionsWith([]int{0, 4}, func(solution []Step) bool {
+		solutions = append(solutions, solution)
+		return true
+	})
+	testExample(t, solutions, [][]Step{
+		[]Step{Step{1, 6, []int{6}, 0, 2, "", ""}},
+	})
+
+	count := 0
+	dl.GenerateSolutionsWith([]int{0, 1}, func([]Step) bool {
+		count++
+		return true
+	})
+	if count != 0 {
+		t.Errorf("conflicting options should rule out solutions, found %d", count)
+	}
+
+	// Options required twice, or already forced, are no conflict.
+	for _, forced := range []bool{false, true} {
+		if forced {
+			dl.ForceOptions(0)
+		}
+		solutions = [][]Step{}
+		dl.GenerateSolutionsWith([]int{0, 4, 0}, func(solution []Step) bool {
+			solutions = append(solutions, solution)
+			return true
+		})
+		testExample(t, solutions, [][]Step{
+			[]Step{Step{1, 6, []int{6}, 0, 2, "", ""}},
+		})
+	}
+	dl.UnforceOptions()
+
+	// The structure is unaffected afterwards.
+	testExample(t, dl.AllSolutions(), classicDuplicates.solution)
+}
+
+func TestAllSolutionsN(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+
+	for limit, truncated := range map[int]bool{0: true, 3: true, 4: false, 5: false} {
+		solutions, ok := dl.AllSolutionsN(limit)
+		correct := classicDuplicates.solution
+		if limit < len(correct) {
+			correct = correct[:limit]
+		}
+		testExample(t, solutions, correct)
+		if ok != truncated {
+			t.Errorf("AllSolutionsN(%d): expected truncated %v, got %v", limit, truncated, ok)
+		}
+
+		correctCovers := [][]int{}
+		for _, solution := range correct {
+			correctCovers = append(correctCovers, coverOf(solution))
+		}
+		covers, ok := dl.AllCoversN(limit)
+		if !reflect.DeepEqual(covers, correctCovers) || ok != truncated {
+			t.Errorf("AllCoversN(%d): expected %v, %v, got %v, %v", limit, correctCovers, truncated, covers, ok)
+		}
+	}
+
+	if solutions, truncated := impossible.toDLX().AllSolutionsN(1); len(solutions) != 0 || truncated {
+		t.Errorf("impossible example: expected no solutions, got %v, %v", solutions, truncated)
+	}
+}
+
+func TestTryAnySolution(t *testing.T) {
+	for _, e := range []example{classic, impossible, trivial} {
+		dl := e.toDLX()
+		solution, ok := dl.TryAnySolution()
+		if ok != (len(e.solution) > 0) {
+			t.Errorf("TryAnySolution: expected ok %v, got %v", len(e.solution) > 0, ok)
+		}
+		if ok && !reflect.DeepEqual(solution, e.solution[0]) {
+			t.Errorf("TryAnySolution: expected %v, got %v", e.solution[0], solution)
+		}
+
+		cover, ok := dl.TryAnyCover()
+		if ok != (len(e.solution) > 0) {
+			t.Errorf("TryAnyCover: expected ok %v, got %v", len(e.solution) > 0, ok)
+		}
+		if ok && !reflect.DeepEqual(cover, coverOf(e.solution[0])) {
+			t.Errorf("TryAnyCover: expected %v, got %v", coverOf(e.solution[0]), cover)
+		}
+	}
+}
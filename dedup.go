@@ -0,0 +1,117 @@
+package dancinglinks
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// A Deduplicated problem merges options covering the same items into a
+// single option, so that the duplicates take neither memory nor
+// search effort, and expands each cover of the merged problem into
+// every combination of the duplicates it stands for when reporting
+// covers.
+type Deduplicated struct {
+	dl *DLX
+
+	// The original indices of the options merged into each option of
+	// dl, in increasing order.
+	copies [][]int
+}
+
+// NewDeduplicated sets up the exact cover problem of New, with options
+// covering the same set of items, in any order, merged into the first
+// of them.
+func NewDeduplicated(itemCount int, options [][]int, settings ...Setting) *Deduplicated {
+	merged := map[string]int{}
+	distinct := [][]int{}
+	copies := [][]int{}
+	for i, option := range options {
+		items := append([]int{}, option...)
+		sort.Ints(items)
+		key := fmt.Sprint(items)
+
+		if index, ok := merged[key]; ok {
+			copies[index] = append(copies[index], i)
+			continue
+		}
+		merged[key] = len(distinct)
+		distinct = append(distinct, option)
+		copies = append(copies, []int{i})
+	}
+
+	return &Deduplicated{
+		dl:     New(itemCount, distinct, settings...),
+		copies: copies,
+	}
+}
+
+// DLX returns the merged problem, whose options are numbered apart
+// from the original ones, for configuring and inspecting it.
+func (d *Deduplicated) DLX() *DLX {
+	return d.dl
+}
+
+// Copies returns the original indices of the options merged into the
+// given option of the merged problem.
+func (d *Deduplicated) Copies(option int) []int {
+	return append([]int{}, d.copies[option]...)
+}
+
+// GenerateCovers yields the covers of the original problem, by their
+// original option indices, each cover of the merged problem expanded
+// into every choice of duplicates.  The caller may keep the yielded
+// covers.
+func (d *Deduplicated) GenerateCovers(yield func([]int) bool) {
+	d.dl.GenerateCoversNoCopy(func(cover []int) bool {
+		return d.expand(cover, make([]int, len(cover)), 0, yield)
+	})
+}
+
+// Yields every cover choosing a duplicate for each option of a merged
+// cover, having chosen them for the first i options already, and
+// reports whether to go on.
+func (d *Deduplicated) expand(cover []int, expanded []int, i int, yield func([]int) bool) bool {
+	if i == len(cover) {
+		return yield(append([]int{}, expanded...))
+	}
+	for _, option := range d.copies[cover[i]] {
+		expanded[i] = option
+		if !d.expand(cover, expanded, i+1, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// AllCovers returns all the covers of the original problem.
+func (d *Deduplicated) AllCovers() [][]int {
+	covers := make([][]int, 0)
+	d.GenerateCovers(func(cover []int) bool {
+		covers = append(covers, cover)
+		return true
+	})
+	return covers
+}
+
+// CountSolutions counts the solutions of the original problem without
+// expanding them, multiplying out the duplicates of each cover of the
+// merged problem.
+func (d *Deduplicated) CountSolutions() *big.Int {
+	total := new(big.Int)
+	product := new(big.Int)
+	d.dl.GenerateCoversNoCopy(func(cover []int) bool {
+		product.SetInt64(1)
+		for _, option := range cover {
+			product.Mul(product, big.NewInt(int64(len(d.copies[option]))))
+		}
+		total.Add(total, product)
+		return true
+	})
+	return total
+}
+
+// HasSolution reports whether the problem has any solution at all.
+func (d *Deduplicated) HasSolution() bool {
+	return d.dl.HasSolution()
+}
@@ -0,0 +1,37 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeduplicated(t *testing.T) {
+	d := NewDeduplicated(classicDuplicates.itemCount, classicDuplicates.options)
+	if count := d.DLX().OptionCount(); count != 6 {
+		t.Errorf("should merge into 6 options, got %d", count)
+	}
+	if copies := d.Copies(0); !reflect.DeepEqual(copies, []int{0, 1}) {
+		t.Errorf("should merge options [0 1], got %v", copies)
+	}
+
+	covers := d.AllCovers()
+	expected := classicDuplicates.toDLX().AllCovers()
+	sortSequences(covers)
+	sortSequences(expected)
+	if !reflect.DeepEqual(covers, expected) {
+		t.Errorf("should be %v, got %v", expected, covers)
+	}
+	if count := d.CountSolutions().Int64(); count != 4 {
+		t.Errorf("should count 4 solutions, counted %d", count)
+	}
+	if !d.HasSolution() {
+		t.Errorf("should have a solution")
+	}
+
+	// Options covering the same items in another order are duplicates
+	// too.
+	d = NewDeduplicated(2, [][]int{{0, 1}, {1, 0}, {0}, {1}})
+	if count := d.CountSolutions().Int64(); count != 3 {
+		t.Errorf("should count 3 solutions, counted %d", count)
+	}
+}
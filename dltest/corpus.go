@@ -0,0 +1,288 @@
+package dltest
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/kwshi/dancinglinks"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+// An Instance is a problem from the corpus of representative
+// workloads, for benchmarking the solver and checking it at scale.
+type Instance struct {
+	// Name identifies the instance, e.g. "pentomino-6x10" or
+	// "sudoku17-3".
+	Name string
+
+	// The number of exact covers.
+	Covers int
+
+	build func() *dancinglinks.DLX
+}
+
+// New builds a fresh DLX for the instance.
+func (instance Instance) New() *dancinglinks.DLX {
+	return instance.build()
+}
+
+// Corpus returns the instances of the corpus, in order of name:
+// pentomino boards, Langford pairings, random covers with a planted
+// solution, and sudoku puzzles with 17 clues.
+func Corpus() []Instance {
+	instances := []Instance{}
+
+	for _, board := range []struct {
+		name   string
+		covers int
+	}{
+		{"pentomino-3x20", 8},
+		{"pentomino-6x10", 9356},
+		{"pentomino-8x8", 520},
+	} {
+		cells := parseBoard(readTestdata(board.name + ".txt"))
+		instances = append(instances, Instance{
+			Name:   board.name,
+			Covers: board.covers,
+			build:  func() *dancinglinks.DLX { return pentomino(cells) },
+		})
+	}
+
+	for _, langford := range []struct{ n, covers int }{
+		{7, 52},
+		{8, 300},
+		{11, 35584},
+	} {
+		n := langford.n
+		instances = append(instances, Instance{
+			Name:   fmt.Sprintf("langford-%d", n),
+			Covers: langford.covers,
+			build:  func() *dancinglinks.DLX { return langfordPairs(n) },
+		})
+	}
+
+	for _, random := range []struct {
+		seed   int64
+		covers int
+	}{
+		{1, 25},
+		{2, 11},
+		{3, 53},
+	} {
+		seed := random.seed
+		instances = append(instances, Instance{
+			Name:   fmt.Sprintf("random-%d", seed),
+			Covers: random.covers,
+			build:  func() *dancinglinks.DLX { return randomCover(seed, 60, 200) },
+		})
+	}
+
+	for i, puzzle := range testdataLines(readTestdata("sudoku17.txt")) {
+		instances = append(instances, Instance{
+			Name:   fmt.Sprintf("sudoku17-%d", i+1),
+			Covers: 1,
+			build:  func() *dancinglinks.DLX { return sudoku(puzzle) },
+		})
+	}
+
+	sort.SliceStable(instances, func(i, j int) bool { return instances[i].Name < instances[j].Name })
+	return instances
+}
+
+// Load returns the instance of the corpus with the given name.
+func Load(name string) (Instance, bool) {
+	for _, instance := range Corpus() {
+		if instance.Name == name {
+			return instance, true
+		}
+	}
+	return Instance{}, false
+}
+
+func readTestdata(name string) []byte {
+	data, err := testdata.ReadFile("testdata/" + name)
+	if err != nil {
+		panic(fmt.Sprintf("dltest: %v", err))
+	}
+	return data
+}
+
+// The lines of a testdata file, leaving out comments and blank lines.
+func testdataLines(data []byte) []string {
+	lines := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// The cells to fill on a board drawn with a dot for each cell, and
+// anything else for holes.
+func parseBoard(data []byte) [][2]int {
+	cells := [][2]int{}
+	for row, line := range testdataLines(data) {
+		for column, c := range line {
+			if c == '.' {
+				cells = append(cells, [2]int{row, column})
+			}
+		}
+	}
+	return cells
+}
+
+// The twelve pentominoes, F through Z, as cells of a grid.
+var pentominoes = [12][5][2]int{
+	{{0, 1}, {0, 2}, {1, 0}, {1, 1}, {2, 1}},
+	{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {4, 0}},
+	{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {3, 1}},
+	{{0, 1}, {1, 1}, {2, 0}, {2, 1}, {3, 0}},
+	{{0, 0}, {0, 1}, {1, 0}, {1, 1}, {2, 0}},
+	{{0, 0}, {0, 1}, {0, 2}, {1, 1}, {2, 1}},
+	{{0, 0}, {0, 2}, {1, 0}, {1, 1}, {1, 2}},
+	{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {2, 2}},
+	{{0, 0}, {1, 0}, {1, 1}, {2, 1}, {2, 2}},
+	{{0, 1}, {1, 0}, {1, 1}, {1, 2}, {2, 1}},
+	{{0, 1}, {1, 0}, {1, 1}, {2, 1}, {3, 1}},
+	{{0, 0}, {0, 1}, {1, 1}, {2, 1}, {2, 2}},
+}
+
+// Packing the twelve pentominoes into the given cells: an item for
+// each piece and each cell, and an option for each placement of a
+// piece, in any orientation.
+func pentomino(cells [][2]int) *dancinglinks.DLX {
+	index := map[[2]int]int{}
+	for i, cell := range cells {
+		index[cell] = len(pentominoes) + i
+	}
+
+	options := [][]int{}
+	for piece, shape := range pentominoes {
+		for _, orientation := range orientations(shape) {
+			for _, origin := range cells {
+				option := []int{piece}
+				for _, offset := range orientation {
+					item, ok := index[[2]int{origin[0] + offset[0], origin[1] + offset[1]}]
+					if !ok {
+						break
+					}
+					option = append(option, item)
+				}
+				if len(option) == 1+len(orientation) {
+					options = append(options, option)
+				}
+			}
+		}
+	}
+	return dancinglinks.New(len(pentominoes)+len(cells), options)
+}
+
+// The distinct rotations and reflections of a shape, each shifted so
+// that its first cell, in reading order, is at the origin.
+func orientations(shape [5][2]int) [][5][2]int {
+	seen := map[[5][2]int]bool{}
+	result := [][5][2]int{}
+	for transform := 0; transform < 8; transform++ {
+		var oriented [5][2]int
+		for i, cell := range shape {
+			r, c := cell[0], cell[1]
+			if transform&1 != 0 {
+				r, c = c, r
+			}
+			if transform&2 != 0 {
+				r = -r
+			}
+			if transform&4 != 0 {
+				c = -c
+			}
+			oriented[i] = [2]int{r, c}
+		}
+
+		sort.Slice(oriented[:], func(i, j int) bool {
+			a, b := oriented[i], oriented[j]
+			return a[0] < b[0] || a[0] == b[0] && a[1] < b[1]
+		})
+		first := oriented[0]
+		for i := range oriented {
+			oriented[i][0] -= first[0]
+			oriented[i][1] -= first[1]
+		}
+
+		if !seen[oriented] {
+			seen[oriented] = true
+			result = append(result, oriented)
+		}
+	}
+	return result
+}
+
+// Langford pairing of 1, 1, 2, 2, ..., n, n: placing each pair of
+// copies of k with exactly k numbers between them, in 2n slots.
+func langfordPairs(n int) *dancinglinks.DLX {
+	options := [][]int{}
+	for k := 1; k <= n; k++ {
+		for slot := 0; slot+k+1 < 2*n; slot++ {
+			options = append(options, []int{k - 1, n + slot, n + slot + k + 1})
+		}
+	}
+	return dancinglinks.New(3*n, options)
+}
+
+// A random problem with the given numbers of items and options, at
+// least one of which form an exact cover.  Options cover two to five
+// items each.
+func randomCover(seed int64, itemCount, optionCount int) *dancinglinks.DLX {
+	rng := rand.New(rand.NewSource(seed))
+	options := [][]int{}
+
+	// Plant a solution, partitioning the items into options.
+	items := rng.Perm(itemCount)
+	for len(items) > 0 {
+		size := min(2+rng.Intn(4), len(items))
+		options = append(options, append([]int{}, items[:size]...))
+		items = items[size:]
+	}
+
+	for len(options) < optionCount {
+		options = append(options, rng.Perm(itemCount)[:2+rng.Intn(4)])
+	}
+
+	rng.Shuffle(len(options), func(i, j int) { options[i], options[j] = options[j], options[i] })
+	return dancinglinks.New(itemCount, options)
+}
+
+// A sudoku puzzle, given row by row with 0 for an empty cell, with the
+// options for its clues forced.
+func sudoku(puzzle string) *dancinglinks.DLX {
+	options := make([][]int, 0, 9*9*9)
+	clues := []int{}
+	for row := 0; row < 9; row++ {
+		for column := 0; column < 9; column++ {
+			block := (row/3)*3 + column/3
+			for value := 0; value < 9; value++ {
+				if puzzle[9*row+column] == byte('1'+value) {
+					clues = append(clues, len(options))
+				}
+				options = append(options, []int{
+					9*row + column,
+					81 + 9*row + value,
+					162 + 9*column + value,
+					243 + 9*block + value,
+				})
+			}
+		}
+	}
+
+	dl := dancinglinks.New(4*81, options)
+	dl.ForceOptions(clues...)
+	return dl
+}
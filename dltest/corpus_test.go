@@ -0,0 +1,39 @@
+package dltest
+
+import (
+	"testing"
+)
+
+func TestCorpus(t *testing.T) {
+	for _, instance := range Corpus() {
+		if testing.Short() && instance.Covers > 1000 {
+			continue
+		}
+
+		dl := instance.New()
+		covers := dl.AllCovers()
+		if len(covers) != instance.Covers {
+			t.Errorf("%s: should have %d covers, found %d", instance.Name, instance.Covers, len(covers))
+		}
+		AssertExactCovers(t, dl, covers)
+	}
+
+	if _, ok := Load("sudoku17-1"); !ok {
+		t.Errorf("should load sudoku17-1")
+	}
+	if _, ok := Load("sudoku17-0"); ok {
+		t.Errorf("should not load sudoku17-0")
+	}
+}
+
+func BenchmarkCorpus(b *testing.B) {
+	for _, instance := range Corpus() {
+		b.Run(instance.Name, func(b *testing.B) {
+			dl := instance.New()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				dl.CountSolutions()
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Package dltest provides helpers for testing code built on
+// dancinglinks, comparing sets of covers regardless of order and
+// checking that covers are exact.
+package dltest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kwshi/dancinglinks"
+)
+
+// CoversOf returns the options selected by each solution.
+func CoversOf(solutions [][]dancinglinks.Step) [][]int {
+	covers := make([][]int, len(solutions))
+	for i, solution := range solutions {
+		covers[i] = make([]int, len(solution))
+		for j, step := range solution {
+			covers[i][j] = step.Option
+		}
+	}
+	return covers
+}
+
+// Canonical returns a copy of the covers with the options of each cover
+// sorted, and the covers sorted lexicographically.
+func Canonical(covers [][]int) [][]int {
+	sorted := make([][]int, len(covers))
+	for i, cover := range covers {
+		sorted[i] = append([]int{}, cover...)
+		sort.Ints(sorted[i])
+	}
+	sort.Slice(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+	return sorted
+}
+
+// Lexicographic comparison of sorted covers.
+func less(a, b []int) bool {
+	for k := 0; k < len(a) && k < len(b); k++ {
+		if a[k] != b[k] {
+			return a[k] < b[k]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// SameCovers reports whether two lists of covers are the same, up to
+// the order of the covers and of the options within each cover.
+func SameCovers(a, b [][]int) bool {
+	return Diff(a, b) == ""
+}
+
+// Diff describes how the covers got differ from the covers want, up
+// to order, listing the missing and unexpected ones, or returns the
+// empty string if they are the same.
+func Diff(want, got [][]int) string {
+	want, got = Canonical(want), Canonical(got)
+
+	var missing, unexpected [][]int
+	i, j := 0, 0
+	for i < len(want) || j < len(got) {
+		switch {
+		case j == len(got) || (i < len(want) && less(want[i], got[j])):
+			missing = append(missing, want[i])
+			i++
+		case i == len(want) || less(got[j], want[i]):
+			unexpected = append(unexpected, got[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+
+	b := &strings.Builder{}
+	for _, cover := range missing {
+		fmt.Fprintf(b, "- %v\n", cover)
+	}
+	for _, cover := range unexpected {
+		fmt.Fprintf(b, "+ %v\n", cover)
+	}
+	return b.String()
+}
+
+// AssertCovers reports an error to t, with a diff, unless the covers
+// got are the same as want, up to order.
+func AssertCovers(t testing.TB, want, got [][]int) {
+	t.Helper()
+	if diff := Diff(want, got); diff != "" {
+		t.Errorf("covers differ (-want +got):\n%s", diff)
+	}
+}
+
+// AssertExactCovers reports an error to t for every cover that is not
+// an exact cover of dl, taking forced options into account, and for
+// every cover listed more than once.
+func AssertExactCovers(t testing.TB, dl *dancinglinks.DLX, covers [][]int) {
+	t.Helper()
+	seen := map[string]bool{}
+	for _, cover := range Canonical(covers) {
+		if err := dl.Verify(cover); err != nil {
+			t.Errorf("cover %v: %v", cover, err)
+		}
+
+		key := fmt.Sprint(cover)
+		if seen[key] {
+			t.Errorf("cover %v listed more than once", cover)
+		}
+		seen[key] = true
+	}
+}
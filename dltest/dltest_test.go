@@ -0,0 +1,57 @@
+package dltest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kwshi/dancinglinks"
+)
+
+func TestCanonical(t *testing.T) {
+	covers := [][]int{{6, 5, 1}, {6, 4, 0}, {0, 5, 6}}
+	expected := [][]int{{0, 4, 6}, {0, 5, 6}, {1, 5, 6}}
+	if sorted := Canonical(covers); !reflect.DeepEqual(sorted, expected) {
+		t.Errorf("should be %v, got %v", expected, sorted)
+	}
+	if !reflect.DeepEqual(covers[0], []int{6, 5, 1}) {
+		t.Errorf("should leave the input alone, got %v", covers)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	if diff := Diff([][]int{{1, 2}, {3}}, [][]int{{3}, {2, 1}}); diff != "" {
+		t.Errorf("should be the same, got diff\n%s", diff)
+	}
+
+	diff := Diff([][]int{{1, 2}, {3}}, [][]int{{3}, {4}})
+	if expected := "- [1 2]\n+ [4]\n"; diff != expected {
+		t.Errorf("should be\n%s\ngot\n%s", expected, diff)
+	}
+	if SameCovers([][]int{{1}}, [][]int{{1}, {1}}) {
+		t.Errorf("repeated covers should count")
+	}
+}
+
+func TestAssertExactCovers(t *testing.T) {
+	dl := dancinglinks.New(3, [][]int{{0, 1}, {2}, {1, 2}, {0}})
+	covers := CoversOf(dl.AllSolutions())
+	AssertCovers(t, [][]int{{0, 1}, {2, 3}}, covers)
+	AssertExactCovers(t, dl, covers)
+
+	// Run the failing assertions against a stand-in.
+	fake := &recorder{TB: t}
+	AssertExactCovers(fake, dl, [][]int{{0}, {0, 1}, {1, 0}})
+	if fake.errors != 2 {
+		t.Errorf("should reject an inexact cover and a repeated one, got %d errors", fake.errors)
+	}
+}
+
+// Counts reported errors instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors int
+}
+
+func (r *recorder) Errorf(string, ...any) {
+	r.errors++
+}
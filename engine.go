@@ -0,0 +1,59 @@
+package dancinglinks
+
+// The data structure the solver searches with.
+type Engine int
+
+const (
+	// Search the linked nodes of the DLX itself.  This is the default,
+	// and supports every feature of the solver.  Searches for covers
+	// alone on problems with at most 64 items are instead run on
+	// bitmasks of the items each option covers, which is much faster.
+	EngineLinked Engine = iota
+
+	// Lay the remaining problem out afresh for each search in a single
+	// array with spacer nodes between options, as in Knuth's Algorithm
+	// C (TAOCP 7.2.2.1), which tends to be faster on large instances.
+	EngineSpacer
+)
+
+// SetEngine sets the data structure used by subsequent searches.  The
+// engine backs GenerateCovers, GenerateCoversNoCopy,
+// GenerateCoversInto, CountSolutions and CountSolutionsUpTo, and the
+// functions built on them, all of which find the same covers in the
+// same order whatever the engine.  Searches involving symmetries,
+// randomization, another order, tie-breaking policy or item order, or
+// a depth limit always use the linked engine.
+func (dl *DLX) SetEngine(engine Engine) {
+	dl.engine = engine
+}
+
+// Searches for covers with the engine set, unless the linked search
+// is needed, in which case it reports false without searching.  The
+// yielded slice is reused.
+func (dl *DLX) searchCovers(yield func([]int) bool) bool {
+	if dl.symmetryPrev != nil ||
+		dl.rng != nil ||
+		dl.order != OrderFewestChoices ||
+		dl.tieBreak != TieLowestIndex ||
+		dl.itemRank != nil ||
+		dl.depthLimit != 0 {
+		return false
+	}
+
+	switch dl.engine {
+	case EngineLinked:
+		b := dl.newBitset()
+		if b == nil {
+			return false
+		}
+		if dl.checkCoverable() {
+			b.search(dl, 0, make([]int, 0, len(b.items)), yield)
+		}
+
+	case EngineSpacer:
+		if dl.checkCoverable() {
+			dl.newSpacer().search(dl, make([]int, 0, len(dl.items)), yield)
+		}
+	}
+	return true
+}
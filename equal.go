@@ -0,0 +1,59 @@
+package dancinglinks
+
+import (
+	"slices"
+	"sort"
+)
+
+// Equal reports whether two problems have the same items, primary and
+// secondary, the same options, covering the same items in the same
+// order with the same colors, and the same forced and disabled
+// options, in any order.  Settings, labels, and payloads are not
+// compared.
+func (dl *DLX) Equal(other *DLX) bool {
+	if len(dl.items) != len(other.items) || len(dl.options) != len(other.options) {
+		return false
+	}
+	for i, item := range dl.items {
+		if item.secondary != other.items[i].secondary {
+			return false
+		}
+	}
+
+	for i := range dl.options {
+		entries, others := slices.Collect(dl.row(i)), slices.Collect(other.row(i))
+		if len(entries) != len(others) {
+			return false
+		}
+		for j, e := range entries {
+			entry := &dl.entries[e]
+			o := &other.entries[others[j]]
+			if entry.item != o.item || entry.color != o.color {
+				return false
+			}
+		}
+	}
+
+	return sameSet(dl.selected, other.selected) && sameSet(dl.disabled, other.disabled)
+}
+
+// Whether two lists of distinct elements hold the same elements.
+func sameSet(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = sortedCopy(a)
+	b = sortedCopy(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedCopy(slice []int) []int {
+	sorted := append([]int{}, slice...)
+	sort.Ints(sorted)
+	return sorted
+}
@@ -0,0 +1,41 @@
+package dancinglinks
+
+import (
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	dl := classic.toDLX()
+	if !dl.Equal(classic.toDLX()) {
+		t.Errorf("classic example should equal itself")
+	}
+	if dl.Equal(classicDuplicates.toDLX()) {
+		t.Errorf("classic example should differ from classicDuplicates")
+	}
+	if dl.Equal(New(classic.itemCount, classic.options, WithSecondaryItems(1))) {
+		t.Errorf("secondary items should matter")
+	}
+	if dl.Equal(New(classic.itemCount, append(append([][]int{}, classic.options[:5]...), []int{3, 6, 4}))) {
+		t.Errorf("item order within options should matter")
+	}
+
+	dl.ForceOptions(0, 4)
+	other := classic.toDLX()
+	other.ForceOptions(4, 0)
+	if !dl.Equal(other) {
+		t.Errorf("forcing order should not matter")
+	}
+	if dl.Equal(classic.toDLX()) {
+		t.Errorf("forced options should matter")
+	}
+
+	dl.DisableOption(3)
+	if dl.Equal(other) {
+		t.Errorf("disabled options should matter")
+	}
+
+	dl.Reset()
+	if !dl.Equal(classic.toDLX()) {
+		t.Errorf("reset instance should equal a fresh one")
+	}
+}
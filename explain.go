@@ -0,0 +1,61 @@
+package dancinglinks
+
+// ExplainInfeasible returns a minimal set of items that cannot be
+// covered together, given the forced options, or nil if the problem
+// has a solution.  Minimal means that the remaining options can cover
+// any proper subset of the returned items, ignoring all other items.
+// Explaining takes a search per item left to cover, and ignores search
+// limits.
+func (dl *DLX) ExplainInfeasible() []int {
+	core := dl.ActiveItems()
+	if dl.coverable(core) {
+		return nil
+	}
+
+	// Drop each item in turn, unless that makes the rest coverable.
+	for i := 0; i < len(core); {
+		rest := append(append([]int{}, core[:i]...), core[i+1:]...)
+		if dl.coverable(rest) {
+			i++
+			continue
+		}
+		core = rest
+	}
+	return core
+}
+
+// Whether the available options can cover the given items, ignoring
+// all other items.
+func (dl *DLX) coverable(items []int) bool {
+	// Renumber the items, primary ones first.
+	indices := make(map[int]int, len(items))
+	primaryCount := 0
+	for _, item := range items {
+		if !dl.items[item].secondary {
+			indices[item] = primaryCount
+			primaryCount++
+		}
+	}
+	secondaryCount := 0
+	for _, item := range items {
+		if dl.items[item].secondary {
+			indices[item] = primaryCount + secondaryCount
+			secondaryCount++
+		}
+	}
+
+	options := [][]ColoredItem{}
+	for _, option := range dl.ActiveOptions() {
+		projected := []ColoredItem{}
+		for e := range dl.row(option) {
+			entry := &dl.entries[e]
+			if index, ok := indices[int(entry.item)]; ok {
+				projected = append(projected, ColoredItem{index, int(entry.color)})
+			}
+		}
+		options = append(options, projected)
+	}
+
+	_, ok := NewColored(primaryCount, secondaryCount, options).TryAnyCover()
+	return ok
+}
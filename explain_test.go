@@ -0,0 +1,40 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExplainInfeasible(t *testing.T) {
+	if core := classic.toDLX().ExplainInfeasible(); core != nil {
+		t.Errorf("classic example is feasible, got %v", core)
+	}
+
+	// Search limits do not cut the check short.
+	dl := classic.toDLX()
+	dl.SetNodeLimit(1)
+	if core := dl.ExplainInfeasible(); core != nil {
+		t.Errorf("classic example is feasible despite the node limit, got %v", core)
+	}
+
+	// Items 3 and 4 are beside the point.
+	dl = New(5, [][]int{[]int{0, 1}, []int{1, 2}, []int{3}, []int{4}})
+	if core := dl.ExplainInfeasible(); !reflect.DeepEqual(core, []int{0, 1, 2}) {
+		t.Errorf("expected core [0 1 2], got %v", core)
+	}
+
+	// An item no option covers explains everything by itself.
+	dl = New(3, [][]int{[]int{0, 1}, []int{1, 2}, []int{0}})
+	dl.AddItem()
+	if core := dl.ExplainInfeasible(); !reflect.DeepEqual(core, []int{3}) {
+		t.Errorf("expected core [3], got %v", core)
+	}
+
+	// Forced options take part.
+	dl = classic.toDLX()
+	dl.ForceOptions(1)
+	if core := dl.ExplainInfeasible(); !reflect.DeepEqual(core, []int{2, 4, 5}) {
+		t.Errorf("expected core [2 4 5], got %v", core)
+	}
+	testExample(t, dl.AllSolutions(), [][]Step{})
+}
@@ -0,0 +1,40 @@
+package dancinglinks
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// Fingerprint returns a hash of everything Equal compares, i.e. the
+// items, the options, and the forced and disabled options, so that
+// equal problems have the same fingerprint, which is stable across
+// runs and platforms.
+func (dl *DLX) Fingerprint() [32]byte {
+	buf := binary.AppendUvarint(nil, uint64(len(dl.items)))
+	for _, item := range dl.items {
+		if item.secondary {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(dl.options)))
+	for option := range dl.options {
+		buf = binary.AppendUvarint(buf, uint64(dl.optionSize(option)))
+		for e := range dl.row(option) {
+			entry := &dl.entries[e]
+			buf = binary.AppendUvarint(buf, uint64(entry.item))
+			buf = binary.AppendVarint(buf, int64(entry.color))
+		}
+	}
+
+	for _, options := range [][]int{dl.selected, dl.disabled} {
+		buf = binary.AppendUvarint(buf, uint64(len(options)))
+		for _, option := range sortedCopy(options) {
+			buf = binary.AppendUvarint(buf, uint64(option))
+		}
+	}
+
+	return sha256.Sum256(buf)
+}
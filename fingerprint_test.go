@@ -0,0 +1,33 @@
+package dancinglinks
+
+import (
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	dl := classic.toDLX()
+	if dl.Fingerprint() != classic.toDLX().Fingerprint() {
+		t.Errorf("equal problems should have equal fingerprints")
+	}
+	if dl.Fingerprint() == classicDuplicates.toDLX().Fingerprint() {
+		t.Errorf("classic and classicDuplicates should have different fingerprints")
+	}
+
+	fresh := dl.Fingerprint()
+	dl.ForceOptions(0, 4)
+	forced := dl.Fingerprint()
+	if forced == fresh {
+		t.Errorf("forcing should change the fingerprint")
+	}
+
+	other := classic.toDLX()
+	other.ForceOptions(4, 0)
+	if other.Fingerprint() != forced {
+		t.Errorf("forcing order should not change the fingerprint")
+	}
+
+	dl.UnforceOptions()
+	if dl.Fingerprint() != fresh {
+		t.Errorf("unforcing should restore the fingerprint")
+	}
+}
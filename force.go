@@ -0,0 +1,111 @@
+package dancinglinks
+
+import (
+	"fmt"
+)
+
+// ConflictError reports a forced option that cannot be selected
+// because it overlaps with another selected option.
+type ConflictError struct {
+	// The option that could not be forced.
+	Option int
+
+	// The previously forced option it overlaps with, which is Option
+	// itself if it had been forced already.
+	With int
+}
+
+func (e *ConflictError) Error() string {
+	if e.Option == e.With {
+		return fmt.Sprintf("dancinglinks: option %d is already forced", e.Option)
+	}
+	return fmt.Sprintf("dancinglinks: option %d conflicts with forced option %d", e.Option, e.With)
+}
+
+// ForceOptionsChecked is like ForceOptions, but returns a
+// *ConflictError if some option cannot be forced because it conflicts
+// with a previously forced option (or was forced already), and an
+// error if some index is out of range or the option is disabled.  In
+// case of an error, none of the given options are forced.
+func (dl *DLX) ForceOptionsChecked(indices ...int) error {
+	selected := len(dl.selected)
+
+	for _, index := range indices {
+		var err error
+		switch {
+		case index < 0 || index >= len(dl.options):
+			err = fmt.Errorf("dancinglinks: option %d out of range [0, %d)", index, len(dl.options))
+		case intSliceContains(dl.disabled, index):
+			err = fmt.Errorf("dancinglinks: option %d is disabled", index)
+		case dl.options[index] != -1 && !dl.optionLive(index):
+			err = &ConflictError{index, dl.conflictingSelection(index)}
+		}
+
+		if err != nil {
+			dl.unforceDownTo(selected)
+			return err
+		}
+
+		dl.ForceOptions(index)
+	}
+	return nil
+}
+
+// Finds a selected option overlapping with the given option, or -1.
+func (dl *DLX) conflictingSelection(option int) int {
+	for _, other := range dl.selected {
+		if other == option || dl.overlap(option, other) {
+			return other
+		}
+	}
+	return -1
+}
+
+// Whether two options cannot both be selected, i.e. cover a common
+// item without agreeing on its color.
+func (dl *DLX) overlap(a, b int) bool {
+	for x := range dl.row(a) {
+		for y := range dl.row(b) {
+			x, y := &dl.entries[x], &dl.entries[y]
+			if x.item == y.item && (x.color == 0 || x.color != y.color) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Selected returns the forced options, in the order they were forced.
+func (dl *DLX) Selected() []int {
+	return append([]int{}, dl.selected...)
+}
+
+// DeletedByForcing returns the options ruled out by the forced options
+// because they conflict with them, in the order they were ruled out.
+// The forced options themselves are not included.
+func (dl *DLX) DeletedByForcing() []int {
+	// Forcing an option leaves the columns of its items holding the
+	// options present at the time, which are exactly those it rules
+	// out unless some earlier forced option did already.
+	seen := make([]bool, len(dl.options))
+	for _, option := range dl.selected {
+		seen[option] = true
+	}
+
+	options := []int{}
+	for _, option := range dl.selected {
+		for e := range dl.row(option) {
+			covered := &dl.entries[e]
+			head := dl.items[covered.item].head
+			for c := int(dl.entries[head].down); c != head; c = int(dl.entries[c].down) {
+				conflict := &dl.entries[c]
+				if seen[conflict.option] || covered.color != 0 && conflict.color == covered.color {
+					continue
+				}
+				seen[conflict.option] = true
+				options = append(options, int(conflict.option))
+			}
+		}
+	}
+	return options
+}
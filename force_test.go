@@ -0,0 +1,111 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestForceOptionsChecked(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	if err := dl.ForceOptionsChecked(0, 6); err != nil {
+		t.Errorf("should force [0 6], got %v", err)
+	}
+
+	err, ok := dl.ForceOptionsChecked(4, 1).(*ConflictError)
+	if !ok || err.Option != 1 || err.With != 0 {
+		t.Errorf("option 1 should conflict with option 0, got %v", err)
+	}
+
+	// Option 4 was not forced after all.
+	testExample(t, dl.AllSolutions(), [][]Step{
+		[]Step{Step{0, 4, []int{4, 5}, 0, 3, "", ""}},
+		[]Step{Step{0, 5, []int{4, 5}, 0, 3, "", ""}},
+	})
+
+	err, ok = dl.ForceOptionsChecked(6).(*ConflictError)
+	if !ok || err.Option != 6 || err.With != 6 {
+		t.Errorf("option 6 should be reported as already forced, got %v", err)
+	}
+
+	if err := dl.ForceOptionsChecked(8); err == nil {
+		t.Errorf("out-of-range option should be rejected")
+	}
+}
+
+func TestUnforceOption(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	dl.ForceOptions(0, 6, 4)
+	dl.UnforceOption(6)
+	testExample(t, dl.AllSolutions(), [][]Step{
+		[]Step{Step{1, 6, []int{6}, 0, 2, "", ""}},
+	})
+
+	dl.UnforceOption(0)
+	dl.UnforceOption(0)
+	testExample(t, dl.AllSolutions(), [][]Step{
+		[]Step{Step{1, 6, []int{6}, 0, 4, "", ""}, Step{2, 0, []int{0, 1}, 1, 2, "", ""}},
+		[]Step{Step{1, 6, []int{6}, 0, 4, "", ""}, Step{2, 1, []int{0, 1}, 1, 2, "", ""}},
+	})
+
+	dl.UnforceOptions()
+	testExample(t, dl.AllSolutions(), classicDuplicates.solution)
+}
+
+func TestReset(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	dl.ForceOptions(0, 6)
+
+	// Abandon a search halfway.
+	search := dl.NewSearch()
+	search.Next()
+
+	dl.Reset()
+	testExample(t, dl.AllSolutions(), classicDuplicates.solution)
+	if len(dl.selected) != 0 {
+		t.Errorf("should have no forced options, got %v", dl.selected)
+	}
+}
+
+func TestForcedState(t *testing.T) {
+	dl := classic.toDLX()
+	dl.ForceOptions(0, 4)
+
+	if selected := dl.Selected(); !reflect.DeepEqual(selected, []int{0, 4}) {
+		t.Errorf("expected selected [0 4], got %v", selected)
+	}
+	if deleted := dl.DeletedByForcing(); !reflect.DeepEqual(deleted, []int{2, 5, 1}) {
+		t.Errorf("expected deleted [2 5 1], got %v", deleted)
+	}
+
+	dl.UnforceOptions()
+	if selected, deleted := dl.Selected(), dl.DeletedByForcing(); len(selected) != 0 || len(deleted) != 0 {
+		t.Errorf("expected nothing forced, got %v and %v", selected, deleted)
+	}
+}
+
+func TestForcedColors(t *testing.T) {
+	// Knuth's XCC example: forcing option 3 covers item q, ruling out
+	// option 0, and colors item x with A, ruling out option 2 but not
+	// option 1.
+	const A, B = 1, 2
+	dl := NewColored(3, 2, [][]ColoredItem{
+		[]ColoredItem{{0, 0}, {1, 0}, {3, 0}, {4, A}},
+		[]ColoredItem{{0, 0}, {2, 0}, {3, A}, {4, 0}},
+		[]ColoredItem{{0, 0}, {3, B}},
+		[]ColoredItem{{1, 0}, {3, A}},
+		[]ColoredItem{{2, 0}, {4, B}},
+	})
+	dl.ForceOptions(3)
+
+	if deleted := dl.DeletedByForcing(); !reflect.DeepEqual(deleted, []int{0, 2}) {
+		t.Errorf("expected deleted [0 2], got %v", deleted)
+	}
+	if covers := dl.AllCovers(); !reflect.DeepEqual(covers, [][]int{[]int{1}}) {
+		t.Errorf("expected covers [[1]], got %v", covers)
+	}
+
+	dl.UnforceOptions()
+	if covers := dl.AllCovers(); !reflect.DeepEqual(covers, [][]int{[]int{3, 1}}) {
+		t.Errorf("expected covers [[3 1]], got %v", covers)
+	}
+}
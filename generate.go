@@ -0,0 +1,22 @@
+package dancinglinks
+
+// NewFromFunc is like New, but gets the items of each option from a
+// generator instead of a slice of all the options, so that huge
+// synthetic problems never need their options spelled out in full.
+// The generator appends the items of the i-th option to buf, which it
+// may reuse, and returns the result.  It is called twice for each
+// option, first to count the entries and then to link them, and must
+// give the same items both times.
+func NewFromFunc(itemCount, optionCount int, option func(i int, buf []int) []int, settings ...Setting) *DLX {
+	entryCount := 0
+	var buf []int
+	for i := 0; i < optionCount; i++ {
+		buf = option(i, buf[:0])
+		entryCount += len(buf)
+	}
+	dl := buildDLX(itemCount, 0, optionCount, entryCount, option, nil)
+	for _, setting := range settings {
+		setting(dl)
+	}
+	return dl
+}
@@ -0,0 +1,45 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+// The options of an n-by-n latin square, as generated one at a time.
+func latinSquareOption(n int) func(i int, buf []int) []int {
+	return func(i int, buf []int) []int {
+		row, column, value := i/(n*n), i/n%n, i%n
+		return append(buf, n*row+column, n*n+n*row+value, 2*n*n+n*column+value)
+	}
+}
+
+func TestNewFromFunc(t *testing.T) {
+	itemCount, options := latinSquare(4)
+	dl := NewFromFunc(itemCount, len(options), latinSquareOption(4))
+	if covers, expected := dl.AllCovers(), New(itemCount, options).AllCovers(); !reflect.DeepEqual(covers, expected) {
+		t.Errorf("should find %v, found %v", expected, covers)
+	}
+
+	// Generating the options one by one spares the slice of them all.
+	itemCount, options = latinSquare(25)
+	generated := testing.AllocsPerRun(5, func() {
+		NewFromFunc(itemCount, len(options), latinSquareOption(25))
+	})
+	if generated > 10 {
+		t.Errorf("construction should barely allocate, allocated %v times", generated)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("should panic when the options change between passes")
+		}
+	}()
+	calls := 0
+	NewFromFunc(2, 1, func(i int, buf []int) []int {
+		calls++
+		if calls == 1 {
+			return append(buf, 0)
+		}
+		return append(buf, 0, 1)
+	})
+}
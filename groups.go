@@ -0,0 +1,131 @@
+package dancinglinks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ItemGroups lays out the items of a problem in named groups, such as
+// the rows, columns, boxes, and cells of a sudoku, so that items can
+// be referred to by group and coordinates rather than by hand-computed
+// index.  Each group is a grid of items with the given dimensions,
+// numbered in row-major order, and the groups follow each other in the
+// order they were added.
+type ItemGroups struct {
+	groups []itemGroup
+	byName map[string]int
+	count  int
+}
+
+// A named group of items, starting at the given item index.
+type itemGroup struct {
+	name   string
+	offset int
+	size   int
+	dims   []int
+}
+
+// NewItemGroups returns an empty layout.
+func NewItemGroups() *ItemGroups {
+	return &ItemGroups{byName: map[string]int{}}
+}
+
+// Add appends a group of items with the given name, which must be new,
+// and dimensions.  A group without dimensions holds a single item.
+func (g *ItemGroups) Add(name string, dims ...int) *ItemGroups {
+	if _, ok := g.byName[name]; ok {
+		panic(fmt.Sprintf("dancinglinks: duplicate item group %q", name))
+	}
+
+	size := 1
+	for _, dim := range dims {
+		if dim < 0 {
+			panic(fmt.Sprintf("dancinglinks: item group %q has negative dimension %d", name, dim))
+		}
+		size *= dim
+	}
+
+	g.byName[name] = len(g.groups)
+	g.groups = append(g.groups, itemGroup{name, g.count, size, append([]int{}, dims...)})
+	g.count += size
+	return g
+}
+
+// Count returns the total number of items in all groups.
+func (g *ItemGroups) Count() int {
+	return g.count
+}
+
+// Item returns the index of the item at the given coordinates in the
+// named group.
+func (g *ItemGroups) Item(group string, coords ...int) int {
+	i, ok := g.byName[group]
+	if !ok {
+		panic(fmt.Sprintf("dancinglinks: unknown item group %q", group))
+	}
+	dims := g.groups[i].dims
+	if len(coords) != len(dims) {
+		panic(fmt.Sprintf("dancinglinks: item group %q takes %d coordinates, got %d", group, len(dims), len(coords)))
+	}
+
+	index := 0
+	for j, coord := range coords {
+		if coord < 0 || coord >= dims[j] {
+			panic(fmt.Sprintf("dancinglinks: coordinate %d of item group %q out of range [0, %d)", coord, group, dims[j]))
+		}
+		index = index*dims[j] + coord
+	}
+	return g.groups[i].offset + index
+}
+
+// Locate returns the group and coordinates of an item.
+func (g *ItemGroups) Locate(item int) (group string, coords []int) {
+	if item < 0 || item >= g.count {
+		panic(fmt.Sprintf("dancinglinks: item %d out of range [0, %d)", item, g.count))
+	}
+
+	for _, group := range g.groups {
+		if item >= group.offset+group.size {
+			continue
+		}
+
+		index := item - group.offset
+		coords = make([]int, len(group.dims))
+		for j := len(group.dims) - 1; j >= 0; j-- {
+			coords[j] = index % group.dims[j]
+			index /= group.dims[j]
+		}
+		return group.name, coords
+	}
+	panic("unreachable")
+}
+
+// Label returns the group-qualified name of an item, such as
+// "cell[3,5]".
+func (g *ItemGroups) Label(item int) string {
+	group, coords := g.Locate(item)
+	if len(coords) == 0 {
+		return group
+	}
+
+	parts := make([]string, len(coords))
+	for i, coord := range coords {
+		parts[i] = strconv.Itoa(coord)
+	}
+	return group + "[" + strings.Join(parts, ",") + "]"
+}
+
+// SetItemGroups names the items after their groups and coordinates,
+// as with Label, so that ItemLabel and solution steps report them that
+// way.  The layout must account for exactly the problem's items.
+func (dl *DLX) SetItemGroups(g *ItemGroups) {
+	if g.count != len(dl.items) {
+		panic(fmt.Sprintf("dancinglinks: item groups hold %d items, expected %d", g.count, len(dl.items)))
+	}
+
+	dl.itemLabels = make([]string, len(dl.items))
+	for item := range dl.itemLabels {
+		dl.itemLabels[item] = g.Label(item)
+	}
+}
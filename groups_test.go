@@ -0,0 +1,61 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestItemGroups(t *testing.T) {
+	g := NewItemGroups().Add("cell", 2, 3).Add("empty", 0).Add("root").Add("value", 4)
+	if count := g.Count(); count != 11 {
+		t.Errorf("expected 11 items, got %d", count)
+	}
+
+	for item, label := range []string{
+		"cell[0,0]", "cell[0,1]", "cell[0,2]", "cell[1,0]", "cell[1,1]", "cell[1,2]",
+		"root",
+		"value[0]", "value[1]", "value[2]", "value[3]",
+	} {
+		if l := g.Label(item); l != label {
+			t.Errorf("Label(%d): expected %q, got %q", item, label, l)
+		}
+
+		group, coords := g.Locate(item)
+		if index := g.Item(group, coords...); index != item {
+			t.Errorf("Item(%q, %v): expected %d, got %d", group, coords, item, index)
+		}
+	}
+
+	dl := New(g.Count(), [][]int{[]int{g.Item("cell", 1, 2), g.Item("root")}})
+	dl.SetItemGroups(g)
+	if label := dl.ItemLabel(5); label != "cell[1,2]" {
+		t.Errorf("expected item 5 to be labeled cell[1,2], got %q", label)
+	}
+}
+
+func TestItemGroupsPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"duplicate group":   func() { NewItemGroups().Add("a").Add("a") },
+		"unknown group":     func() { NewItemGroups().Add("a").Item("b") },
+		"wrong coordinates": func() { NewItemGroups().Add("a", 2).Item("a", 0, 0) },
+		"out of range":      func() { NewItemGroups().Add("a", 2).Item("a", 2) },
+		"wrong item count":  func() { New(3, nil).SetItemGroups(NewItemGroups().Add("a", 2)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected a panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestItemGroupsCoords(t *testing.T) {
+	g := NewItemGroups().Add("a", 3).Add("b", 2, 2)
+	group, coords := g.Locate(5)
+	if group != "b" || !reflect.DeepEqual(coords, []int{1, 0}) {
+		t.Errorf("expected b [1 0], got %s %v", group, coords)
+	}
+}
@@ -0,0 +1,145 @@
+package dancinglinks
+
+// MinHittingSet solves the dual of the exact cover problem: it returns
+// a smallest set of items such that every option contains at least
+// one of them.  Forced options are ignored, i.e. all options of the
+// original problem count.  If some option is empty, there is no
+// hitting set, and ok is false.
+func (dl *DLX) MinHittingSet() (items []int, ok bool) {
+	h := &hitter{
+		dl:          dl,
+		itemOptions: make([][]int, len(dl.items)),
+		hits:        make([]int, len(dl.options)),
+		banned:      make([]bool, len(dl.items)),
+		chosen:      []int{},
+	}
+	for option, first := range dl.options {
+		if first == -1 {
+			return nil, false
+		}
+		for e := range dl.row(option) {
+			entry := &h.dl.entries[e]
+			h.itemOptions[entry.item] = append(h.itemOptions[entry.item], option)
+		}
+	}
+
+	h.search()
+	return h.best, true
+}
+
+// Bookkeeping for the branch-and-bound search for a minimum hitting
+// set.
+type hitter struct {
+	dl *DLX
+
+	// The options containing each item.
+	itemOptions [][]int
+
+	// How many chosen items each option contains.
+	hits []int
+
+	// Items ruled out on the current search path, since the branches
+	// choosing them have been explored already.
+	banned []bool
+
+	// Items chosen on the current search path, and the best hitting
+	// set found so far (nil if none).
+	chosen []int
+	best   []int
+}
+
+func (h *hitter) search() {
+	if h.best != nil && len(h.chosen)+h.bound() >= len(h.best) {
+		return
+	}
+
+	// Branch on the option not yet hit with the fewest items left to
+	// hit it with.
+	option, size := -1, 0
+	for o := range h.dl.options {
+		if h.hits[o] > 0 {
+			continue
+		}
+
+		n := 0
+		for e := range h.dl.row(o) {
+			entry := &h.dl.entries[e]
+			if !h.banned[entry.item] {
+				n++
+			}
+		}
+		if option == -1 || n < size {
+			option, size = o, n
+		}
+	}
+
+	if option == -1 {
+		h.best = append([]int{}, h.chosen...)
+		return
+	}
+
+	// Choose each item of the option in turn, ruling it out in the
+	// subsequent branches.
+	banned := []int{}
+	for e := range h.dl.row(option) {
+		entry := &h.dl.entries[e]
+		item := int(entry.item)
+		if h.banned[item] {
+			continue
+		}
+
+		h.choose(item, 1)
+		h.search()
+		h.choose(item, -1)
+
+		h.banned[item] = true
+		banned = append(banned, item)
+	}
+
+	for _, item := range banned {
+		h.banned[item] = false
+	}
+}
+
+// Adds (delta = 1) or removes (delta = -1) an item from the chosen set.
+func (h *hitter) choose(item int, delta int) {
+	if delta > 0 {
+		h.chosen = append(h.chosen, item)
+	} else {
+		h.chosen = h.chosen[:len(h.chosen)-1]
+	}
+	for _, option := range h.itemOptions[item] {
+		h.hits[option] += delta
+	}
+}
+
+// A lower bound on the number of items still needed: options not yet
+// hit that share no items need distinct items to hit them.
+func (h *hitter) bound() int {
+	used := map[int]bool{}
+	bound := 0
+	for o := range h.dl.options {
+		if h.hits[o] > 0 {
+			continue
+		}
+
+		disjoint := true
+		for e := range h.dl.row(o) {
+			entry := &h.dl.entries[e]
+			if used[int(entry.item)] {
+				disjoint = false
+				break
+			}
+		}
+		if !disjoint {
+			continue
+		}
+
+		for e := range h.dl.row(o) {
+			entry := &h.dl.entries[e]
+			used[int(entry.item)] = true
+		}
+		bound++
+	}
+	return bound
+}
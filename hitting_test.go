@@ -0,0 +1,37 @@
+package dancinglinks
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestMinHittingSet(t *testing.T) {
+	items, ok := classic.toDLX().MinHittingSet()
+	if !ok || len(items) != 3 {
+		t.Fatalf("should find a hitting set of 3 items, got %v (ok=%v)", items, ok)
+	}
+
+	hit := map[int]bool{}
+	for _, item := range items {
+		hit[item] = true
+	}
+	for i, option := range classic.options {
+		found := false
+		for _, item := range option {
+			found = found || hit[item]
+		}
+		if !found {
+			t.Errorf("option %d is not hit by %v", i, items)
+		}
+	}
+
+	items, _ = New(3, [][]int{[]int{0, 1}, []int{1, 2}}).MinHittingSet()
+	sort.Ints(items)
+	if len(items) != 1 || items[0] != 1 {
+		t.Errorf("should be [1], got %v", items)
+	}
+
+	if _, ok := New(1, [][]int{[]int{}}).MinHittingSet(); ok {
+		t.Errorf("empty option should not be hit")
+	}
+}
@@ -0,0 +1,55 @@
+package dancinglinks
+
+import (
+	"sort"
+)
+
+// NewWithIDs is like FromSparse, but the options refer to items by
+// arbitrary identifiers, such as hashes, rather than by index.  The
+// items are numbered in increasing order of their identifiers; ItemID
+// and ItemIndex translate between the two.
+func NewWithIDs(options [][]uint64) *DLX {
+	indices := map[uint64]int{}
+	ids := []uint64{}
+	for _, option := range options {
+		for _, id := range option {
+			if _, ok := indices[id]; !ok {
+				indices[id] = 0
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for i, id := range ids {
+		indices[id] = i
+	}
+
+	plain := make([][]int, len(options))
+	for i, option := range options {
+		plain[i] = make([]int, len(option))
+		for j, id := range option {
+			plain[i][j] = indices[id]
+		}
+	}
+
+	dl := New(len(ids), plain)
+	dl.itemIDs = ids
+	dl.itemIndices = indices
+	return dl
+}
+
+// ItemID returns the identifier of an item, or false if the item was
+// not given by identifier.
+func (dl *DLX) ItemID(item int) (uint64, bool) {
+	if item >= len(dl.itemIDs) {
+		return 0, false
+	}
+	return dl.itemIDs[item], true
+}
+
+// ItemIndex returns the index of the item with the given identifier,
+// or false if there is no such item.
+func (dl *DLX) ItemIndex(id uint64) (int, bool) {
+	index, ok := dl.itemIndices[id]
+	return index, ok
+}
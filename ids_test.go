@@ -0,0 +1,43 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewWithIDs(t *testing.T) {
+	// The classic example, with item i renamed to 1<<(60-i).
+	ids := make([][]uint64, len(classic.options))
+	for i, option := range classic.options {
+		ids[i] = make([]uint64, len(option))
+		for j, item := range option {
+			ids[i][j] = 1 << (60 - item)
+		}
+	}
+
+	dl := NewWithIDs(ids)
+	if covers, expected := coverSet(dl.AllSolutions()), coverSet(classic.solution); !reflect.DeepEqual(covers, expected) {
+		t.Errorf("should be %v, got %v", expected, covers)
+	}
+	if n := dl.ItemCount(); n != classic.itemCount {
+		t.Errorf("expected %d items, got %d", classic.itemCount, n)
+	}
+
+	// Items are numbered by increasing identifier.
+	for item := 0; item < classic.itemCount; item++ {
+		id, ok := dl.ItemID(item)
+		if want := uint64(1) << (54 + item); !ok || id != want {
+			t.Errorf("ItemID(%d): expected %d, got %d, %v", item, want, id, ok)
+		}
+		if index, ok := dl.ItemIndex(id); !ok || index != item {
+			t.Errorf("ItemIndex(%d): expected %d, got %d, %v", id, item, index, ok)
+		}
+	}
+
+	if _, ok := dl.ItemIndex(12345); ok {
+		t.Errorf("expected an unknown identifier")
+	}
+	if _, ok := classic.toDLX().ItemID(0); ok {
+		t.Errorf("expected no identifiers for items given by index")
+	}
+}
@@ -0,0 +1,70 @@
+package dancinglinks
+
+// ItemCount returns the number of items, primary and secondary.
+func (dl *DLX) ItemCount() int {
+	return len(dl.items)
+}
+
+// OptionCount returns the number of options.
+func (dl *DLX) OptionCount() int {
+	return len(dl.options)
+}
+
+// Option returns the indices of the items covered by an option, in
+// the order they were given.
+func (dl *DLX) Option(index int) []int {
+	items := []int{}
+	for e := range dl.row(index) {
+		items = append(items, int(dl.entries[e].item))
+	}
+	return items
+}
+
+// RemainingChoices returns the options still available to cover an
+// item, taking forced options into account.  An item covered by a
+// forced option has no remaining choices.
+func (dl *DLX) RemainingChoices(item int) []int {
+	// A covered item's column keeps the options hidden by the cover,
+	// so filter them out.
+	head := dl.items[item].head
+	choices := []int{}
+	for entry := int(dl.entries[head].down); entry != head; entry = int(dl.entries[entry].down) {
+		if option := int(dl.entries[entry].option); dl.optionLive(option) {
+			choices = append(choices, option)
+		}
+	}
+	return choices
+}
+
+// ActiveItems returns the items, primary or secondary, that no forced
+// option covers, in increasing order.
+func (dl *DLX) ActiveItems() []int {
+	covered := make([]bool, len(dl.items))
+	for _, option := range dl.selected {
+		for e := range dl.row(option) {
+			entry := &dl.entries[e]
+			covered[entry.item] = true
+		}
+	}
+
+	items := []int{}
+	for item, isCovered := range covered {
+		if !isCovered {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ActiveOptions returns the options that are still available, i.e.
+// neither forced nor ruled out by a forced option, in increasing
+// order.  Options without any items are never available.
+func (dl *DLX) ActiveOptions() []int {
+	options := []int{}
+	for option := range dl.options {
+		if dl.optionLive(option) {
+			options = append(options, option)
+		}
+	}
+	return options
+}
@@ -0,0 +1,63 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIntrospection(t *testing.T) {
+	dl := classic.toDLX()
+	dl.ForceOptions(0)
+
+	if count := dl.ItemCount(); count != classic.itemCount {
+		t.Errorf("ItemCount: expected %d, got %d", classic.itemCount, count)
+	}
+	if count := dl.OptionCount(); count != len(classic.options) {
+		t.Errorf("OptionCount: expected %d, got %d", len(classic.options), count)
+	}
+	for i, option := range classic.options {
+		if items := dl.Option(i); !reflect.DeepEqual(items, option) {
+			t.Errorf("Option(%d): expected %v, got %v", i, option, items)
+		}
+	}
+}
+
+func TestRemainingChoices(t *testing.T) {
+	dl := classic.toDLX()
+	dl.ForceOptions(0)
+
+	for item, correct := range map[int][]int{
+		0: []int{1, 3},
+		2: []int{},
+		6: []int{1, 4},
+	} {
+		if choices := dl.RemainingChoices(item); !reflect.DeepEqual(choices, correct) {
+			t.Errorf("RemainingChoices(%d): expected %v, got %v", item, correct, choices)
+		}
+	}
+
+	dl.UnforceOptions()
+	if choices := dl.RemainingChoices(2); !reflect.DeepEqual(choices, []int{0, 2}) {
+		t.Errorf("RemainingChoices(2) after unforcing: expected [0 2], got %v", choices)
+	}
+}
+
+func TestActive(t *testing.T) {
+	dl := classic.toDLX()
+	dl.ForceOptions(0)
+
+	if items := dl.ActiveItems(); !reflect.DeepEqual(items, []int{0, 1, 3, 5, 6}) {
+		t.Errorf("ActiveItems: expected [0 1 3 5 6], got %v", items)
+	}
+	if options := dl.ActiveOptions(); !reflect.DeepEqual(options, []int{1, 3, 4}) {
+		t.Errorf("ActiveOptions: expected [1 3 4], got %v", options)
+	}
+
+	dl.UnforceOptions()
+	if items := dl.ActiveItems(); len(items) != classic.itemCount {
+		t.Errorf("ActiveItems after unforcing: expected all items, got %v", items)
+	}
+	if options := dl.ActiveOptions(); len(options) != len(classic.options) {
+		t.Errorf("ActiveOptions after unforcing: expected all options, got %v", options)
+	}
+}
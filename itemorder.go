@@ -0,0 +1,72 @@
+package dancinglinks
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SetItemOrder sets the order in which the solver prefers to branch on
+// items tied for the fewest remaining choices: ties go to the item
+// coming first in items, and items left out come after all the listed
+// ones, by index.  The items keep their indices, so covers and steps
+// are unaffected apart from the order in which they are found.  A nil
+// order restores the default of branching on the lowest index.  The
+// order only matters with the TieLowestIndex and TieLastTouched
+// policies, for which it replaces the index when breaking ties, and
+// with OrderStatic, which branches in this order.
+func (dl *DLX) SetItemOrder(items []int) {
+	dl.buckets = nil
+	if items == nil {
+		dl.itemRank = nil
+		return
+	}
+
+	dl.itemRank = make([]int, len(dl.items))
+	for i := range dl.itemRank {
+		dl.itemRank[i] = len(items) + i
+	}
+	for rank, item := range items {
+		if item < 0 || item >= len(dl.items) {
+			panic(fmt.Sprintf("dancinglinks: item %d out of range [0, %d)", item, len(dl.items)))
+		}
+		dl.itemRank[item] = rank
+	}
+}
+
+// OrderItemsByOptions sets the item order, as SetItemOrder does, to
+// branch first on the items covered by the fewest options still
+// available, with ties by index.  Preferring the most constrained
+// items among those the solver finds equally constrained at each step
+// often prunes the search much earlier.
+func (dl *DLX) OrderItemsByOptions() {
+	items := make([]int, len(dl.items))
+	for i := range items {
+		items[i] = i
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return dl.items[items[i]].choices < dl.items[items[j]].choices
+	})
+	dl.SetItemOrder(items)
+}
+
+// Whether one item goes before another when breaking ties, by the item
+// order if one is set, or else by index.  Items added after the order
+// was set go last.
+func (dl *DLX) itemBefore(item, other int) bool {
+	return itemRanked(dl.itemRank, item, other)
+}
+
+func itemRanked(rank []int, item, other int) bool {
+	if rank == nil {
+		return item < other
+	}
+
+	r, s := len(rank)+item, len(rank)+other
+	if item < len(rank) {
+		r = rank[item]
+	}
+	if other < len(rank) {
+		s = rank[other]
+	}
+	return r < s
+}
@@ -0,0 +1,49 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestItemOrder(t *testing.T) {
+	defer func(threshold int) { itemBucketThreshold = threshold }(itemBucketThreshold)
+
+	itemCount, options := latinSquare(3)
+	reversed := make([]int, itemCount)
+	for i := range reversed {
+		reversed[i] = itemCount - 1 - i
+	}
+
+	// All items start out with three choices, so the order alone picks
+	// the first item to branch on.
+	for _, threshold := range []int{512, 0} {
+		itemBucketThreshold = threshold
+		dl := New(itemCount, options, WithItemOrder(reversed))
+		solutions := dl.AllSolutions()
+		if item := solutions[0][0].Item; item != itemCount-1 {
+			t.Errorf("threshold %d: should branch on item %d first, branched on %d", threshold, itemCount-1, item)
+		}
+
+		dl.SetItemOrder(nil)
+		if covers, expected := coverSet(solutions), coverSet(dl.AllSolutions()); !reflect.DeepEqual(covers, expected) {
+			t.Errorf("threshold %d: should find %v, found %v", threshold, expected, covers)
+		}
+	}
+
+	// Item 2 is covered by the fewest options, and items left out of
+	// the order come after the listed ones.
+	dl := New(4, [][]int{{0, 1}, {0, 3}, {1, 2}, {3}, {2, 3}, {1}}, WithItemOrder([]int{2}))
+	if _, options := dl.nextChoices(nil); !reflect.DeepEqual(options, []int{2, 4}) {
+		t.Errorf("should branch on item 2 first, got options %v", options)
+	}
+
+	// Items 2, 0 and 3, and 1 are covered by one, two, and three
+	// options.
+	dl = New(4, [][]int{{0, 1}, {1, 2}, {0, 3}, {3, 1}})
+	dl.OrderItemsByOptions()
+	for _, pair := range [][2]int{{2, 0}, {0, 3}, {3, 1}} {
+		if !dl.itemBefore(pair[0], pair[1]) || dl.itemBefore(pair[1], pair[0]) {
+			t.Errorf("item %d should come before item %d", pair[0], pair[1])
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package dancinglinks
+
+import (
+	"iter"
+)
+
+// Solutions returns an iterator over the solutions, for use with
+// range-over-func loops.  Breaking out of the loop stops the search.
+func (dl *DLX) Solutions() iter.Seq[[]Step] {
+	return func(yield func([]Step) bool) {
+		dl.GenerateSolutions(yield)
+	}
+}
+
+// Covers returns an iterator over the covers, for use with
+// range-over-func loops.  Breaking out of the loop stops the search.
+func (dl *DLX) Covers() iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		dl.GenerateCovers(yield)
+	}
+}
@@ -0,0 +1,26 @@
+package dancinglinks
+
+import (
+	"testing"
+)
+
+func TestSolutionsIterator(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	solutions := [][]Step{}
+	for solution := range dl.Solutions() {
+		solutions = append(solutions, solution)
+	}
+	testExample(t, solutions, classicDuplicates.solution)
+
+	count := 0
+	for range dl.Covers() {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("should stop after 2 covers, got %d", count)
+	}
+	testExample(t, dl.AllSolutions(), classicDuplicates.solution)
+}
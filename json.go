@@ -0,0 +1,191 @@
+package dancinglinks
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// The serializable form of a problem, as encoded in JSON and
+// Protobuf.
+type problemData struct {
+	// The number of items, and the indices of the secondary ones.
+	Items     int   `json:"items"`
+	Secondary []int `json:"secondary,omitempty"`
+
+	// The items of each option, and the colors it assigns them, if
+	// any option assigns any.
+	Options [][]int `json:"options"`
+	Colors  [][]int `json:"colors,omitempty"`
+
+	ItemLabels   []string `json:"itemLabels,omitempty"`
+	OptionLabels []string `json:"optionLabels,omitempty"`
+
+	Forced   []int `json:"forced,omitempty"`
+	Disabled []int `json:"disabled,omitempty"`
+}
+
+// MarshalJSON encodes the problem definition: its items, options,
+// colors, labels, and forced and disabled options.  Settings, costs,
+// and payloads are not encoded.
+func (dl *DLX) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dl.data())
+}
+
+// The problem definition, for encoding.
+func (dl *DLX) data() problemData {
+	p := problemData{
+		Items:        len(dl.items),
+		Options:      make([][]int, len(dl.options)),
+		ItemLabels:   dl.itemLabels,
+		OptionLabels: dl.optionLabels,
+		Forced:       dl.selected,
+		Disabled:     dl.disabled,
+	}
+	for i, item := range dl.items {
+		if item.secondary {
+			p.Secondary = append(p.Secondary, i)
+		}
+	}
+
+	colors := make([][]int, len(dl.options))
+	colored := false
+	for i := range dl.options {
+		p.Options[i] = []int{}
+		colors[i] = []int{}
+		for e := range dl.row(i) {
+			entry := &dl.entries[e]
+			p.Options[i] = append(p.Options[i], int(entry.item))
+			colors[i] = append(colors[i], int(entry.color))
+			colored = colored || entry.color != 0
+		}
+	}
+	if colored {
+		p.Colors = colors
+	}
+	return p
+}
+
+// UnmarshalJSON replaces the problem with one encoded by MarshalJSON,
+// with default settings.
+func (dl *DLX) UnmarshalJSON(data []byte) error {
+	var p problemData
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	decoded, err := p.build()
+	if err != nil {
+		return err
+	}
+	*dl = *decoded
+	return nil
+}
+
+// Sets up the decoded problem, checking that it is consistent.
+func (p *problemData) build() (*DLX, error) {
+	// Check the options as NewChecked does.
+	if err := validate(p.Items, p.Options); err != nil {
+		return nil, err
+	}
+	inRange := func(what string, indices []int, count int) error {
+		for _, index := range indices {
+			if index < 0 || index >= count {
+				return fmt.Errorf("dancinglinks: %s %d out of range [0, %d)", what, index, count)
+			}
+		}
+		return nil
+	}
+	for _, check := range []error{
+		inRange("secondary item", p.Secondary, p.Items),
+		inRange("forced option", p.Forced, len(p.Options)),
+		inRange("disabled option", p.Disabled, len(p.Options)),
+	} {
+		if check != nil {
+			return nil, check
+		}
+	}
+	switch {
+	case p.Colors != nil && len(p.Colors) != len(p.Options):
+		return nil, fmt.Errorf("dancinglinks: %d options but colors for %d", len(p.Options), len(p.Colors))
+	case p.ItemLabels != nil && len(p.ItemLabels) != p.Items:
+		return nil, fmt.Errorf("dancinglinks: %d items but %d labels", p.Items, len(p.ItemLabels))
+	case p.OptionLabels != nil && len(p.OptionLabels) != len(p.Options):
+		return nil, fmt.Errorf("dancinglinks: %d options but %d labels", len(p.Options), len(p.OptionLabels))
+	}
+
+	decoded := newDLX(p.Items, 0, p.Options, nil)
+	for _, item := range p.Secondary {
+		decoded.makeSecondary(item)
+	}
+	for i, colors := range p.Colors {
+		if len(colors) != len(p.Options[i]) {
+			return nil, fmt.Errorf("dancinglinks: option %d covers %d items but has %d colors", i, len(p.Options[i]), len(colors))
+		}
+		j := 0
+		for e := range decoded.row(i) {
+			if colors[j] < math.MinInt32 || colors[j] > math.MaxInt32 {
+				return nil, fmt.Errorf("dancinglinks: color %d out of range", colors[j])
+			}
+			if decoded.items[decoded.entries[e].item].secondary {
+				decoded.entries[e].color = int32(colors[j])
+			}
+			j++
+		}
+	}
+
+	decoded.itemLabels = p.ItemLabels
+	decoded.optionLabels = p.OptionLabels
+	if err := decoded.disableAndForce(p.Disabled, p.Forced); err != nil {
+		return nil, err
+	}
+
+	return decoded, nil
+}
+
+// The JSON form of a solution.
+type solutionJSON struct {
+	Options []int    `json:"options"`
+	Labels  []string `json:"labels,omitempty"`
+}
+
+// MarshalJSON encodes the selected options, along with their names if
+// the problem is labeled.
+func (s Solution) MarshalJSON() ([]byte, error) {
+	j := solutionJSON{Options: s.Options}
+	if s.dl != nil && s.dl.optionLabels != nil {
+		j.Labels = s.Labels()
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes the selected options.  The decoded solution
+// does not know its problem; pass its Options to DLX.Solution to
+// translate them back to names and payloads.
+func (s *Solution) UnmarshalJSON(data []byte) error {
+	var j solutionJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*s = Solution{Options: j.Options}
+	return nil
+}
+
+// Disables and forces the given options of a freshly decoded problem,
+// checking that the forced options can all be selected.  Options may be
+// both disabled and forced, as DisableOption leaves forced options
+// forced.
+func (dl *DLX) disableAndForce(disabled, forced []int) error {
+	if err := dl.ForceOptionsChecked(forced...); err != nil {
+		return err
+	}
+	dl.UnforceOptions()
+	seen := make([]bool, len(dl.options))
+	for _, option := range disabled {
+		if !seen[option] {
+			seen[option] = true
+			dl.disabled = append(dl.disabled, option)
+		}
+	}
+	dl.reapply(forced)
+	return nil
+}
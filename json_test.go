@@ -0,0 +1,106 @@
+package dancinglinks
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	labeled, err := NewLabeled(sushiItems, sushiOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	labeled.DisableOption(5)
+	labeled.ForceOptions(0)
+	labeled.DisableOption(0)
+
+	for _, dl := range []*DLX{
+		classicDuplicates.toDLX(),
+		queens(4),
+		NewColored(3, 2, [][]ColoredItem{
+			{{0, 0}, {1, 0}, {3, 0}, {4, 1}},
+			{{0, 0}, {2, 0}, {3, 1}, {4, 0}},
+			{{0, 0}, {3, 2}},
+			{{1, 0}, {3, 1}},
+			{{2, 0}, {4, 2}},
+		}),
+		New(3, [][]int{{0}, {1, 2}}, WithSecondaryItems(1)),
+		labeled,
+	} {
+		data, err := json.Marshal(dl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := &DLX{}
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatalf("should decode %s, got %v", data, err)
+		}
+		if !decoded.Equal(dl) {
+			t.Errorf("should decode %s to %v, got %v", data, dl, decoded)
+		}
+		if got, want := decoded.String(), dl.String(); got != want {
+			t.Errorf("should decode %s to\n%s\ngot\n%s", data, want, got)
+		}
+		if got, want := decoded.AllSolutions(), dl.AllSolutions(); !reflect.DeepEqual(got, want) {
+			t.Errorf("should solve %s like the original, found %v instead of %v", data, got, want)
+		}
+	}
+
+	for data, sentinel := range map[string]error{
+		`{"items": -1}`:                          ErrNegativeItemCount,
+		`{"items": 9000000000000000000}`:         ErrTooManyItems,
+		`{"items": 2, "options": [[0, 2]]}`:      ErrItemOutOfRange,
+		`{"items": 2, "options": [[0, 0], [1]]}`: ErrDuplicateItem,
+	} {
+		if err := json.Unmarshal([]byte(data), &DLX{}); !errors.Is(err, sentinel) {
+			t.Errorf("should reject %s with %v, got %v", data, sentinel, err)
+		}
+	}
+	for _, data := range []string{
+		`{"items": 2, "secondary": [2]}`,
+		`{"items": 2, "options": [[0], [0, 1]], "forced": [0, 1]}`,
+		`{"items": 2, "options": [[0]], "colors": [[0, 1]]}`,
+		`{"items": 2, "options": [[0]], "itemLabels": ["a"]}`,
+	} {
+		if err := json.Unmarshal([]byte(data), &DLX{}); err == nil {
+			t.Errorf("should reject %s", data)
+		}
+	}
+}
+
+func TestSolutionJSON(t *testing.T) {
+	dl, err := NewLabeled(sushiItems, sushiOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	solution := dl.AnySolution()
+	data, err := json.Marshal(solution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var steps []Step
+	if err := json.Unmarshal(data, &steps); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(steps, solution) {
+		t.Errorf("should decode %s to %v, got %v", data, solution, steps)
+	}
+
+	data, err = json.Marshal(dl.Solution(dl.AnyCover()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"options":[3,4,0],"labels":["local specials","catch of the day","chef's choice"]}`; string(data) != want {
+		t.Errorf("should encode to %s, got %s", want, data)
+	}
+	var s Solution
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s.Options, []int{3, 4, 0}) {
+		t.Errorf("should decode to [3 4 0], got %v", s.Options)
+	}
+}
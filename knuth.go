@@ -0,0 +1,201 @@
+package dancinglinks
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseKnuth reads a problem in the text format of Knuth's dlx and xcc
+// programs, returning it labeled.  Lines starting with '|' are
+// comments, and blank lines are skipped.  The first other line names
+// the primary items, followed by '|' and the secondary items, if any.
+// Each remaining line is an option, listing the names of its items; a
+// secondary item may carry a color, as in "x:A".  Colors are numbered
+// from 1 in order of appearance, and each option is labeled with its
+// items as written, separated by single spaces.  Multiplicities of
+// primary items, as in "2:3|p", are not supported.
+func ParseKnuth(r io.Reader) (*DLX, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	line := 0
+	next := func() ([]string, bool) {
+		for scanner.Scan() {
+			line++
+			text := scanner.Text()
+			if strings.HasPrefix(text, "|") {
+				continue
+			}
+			if fields := strings.Fields(text); len(fields) > 0 {
+				return fields, true
+			}
+		}
+		return nil, false
+	}
+	fail := func(format string, args ...any) (*DLX, error) {
+		return nil, fmt.Errorf("dancinglinks: line %d: %s", line, fmt.Sprintf(format, args...))
+	}
+
+	header, ok := next()
+	if !ok {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return fail("missing item names")
+	}
+
+	items := []string{}
+	indices := map[string]int{}
+	primaryCount := -1
+	for _, name := range header {
+		if name == "|" {
+			if primaryCount != -1 {
+				return fail("more than one '|' among the items")
+			}
+			primaryCount = len(items)
+			continue
+		}
+		if strings.ContainsAny(name, ":|") {
+			return fail("item %q is not supported", name)
+		}
+		if _, ok := indices[name]; ok {
+			return fail("duplicate item %q", name)
+		}
+		indices[name] = len(items)
+		items = append(items, name)
+	}
+	if primaryCount == -1 {
+		primaryCount = len(items)
+	}
+
+	stream := NewStream(primaryCount, len(items)-primaryCount)
+	optionLabels := []string{}
+	colors := map[string]int{}
+	option := []ColoredItem{}
+	for {
+		fields, ok := next()
+		if !ok {
+			break
+		}
+
+		option = option[:0]
+		for _, field := range fields {
+			name, color, colored := strings.Cut(field, ":")
+			index, ok := indices[name]
+			if !ok {
+				return fail("unknown item %q", name)
+			}
+			for _, other := range option {
+				if other.Item == index {
+					return fail("item %q appears twice", name)
+				}
+			}
+
+			item := ColoredItem{Item: index}
+			if colored {
+				if index < primaryCount {
+					return fail("primary item %q cannot be colored", name)
+				}
+				if color == "" {
+					return fail("item %q has an empty color", name)
+				}
+				if _, ok := colors[color]; !ok {
+					colors[color] = len(colors) + 1
+				}
+				item.Color = colors[color]
+			}
+			option = append(option, item)
+		}
+
+		stream.AddColoredOption(option...)
+		optionLabels = append(optionLabels, strings.Join(fields, " "))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	dl := stream.Seal()
+	dl.itemLabels = items
+	dl.optionLabels = optionLabels
+	return dl, nil
+}
+
+// A KnuthWriter prints covers the way Knuth's programs print solutions,
+// so that their output can be compared: each option on a line of its
+// own, covers separated by blank lines, and finally the number of
+// covers.  Options are printed by their labels if the problem's
+// options are labeled, as with ParseKnuth, or else by the names of
+// their items, with any colors as numbers.
+type KnuthWriter struct {
+	dl    *DLX
+	w     *bufio.Writer
+	count int
+}
+
+// NewKnuthWriter returns a KnuthWriter printing covers of the given
+// problem to w.
+func NewKnuthWriter(w io.Writer, dl *DLX) *KnuthWriter {
+	return &KnuthWriter{dl: dl, w: bufio.NewWriter(w)}
+}
+
+// WriteCover prints a cover, listing its options in the given order.
+func (k *KnuthWriter) WriteCover(cover []int) error {
+	if k.count > 0 {
+		k.w.WriteByte('\n')
+	}
+	k.count++
+
+	for _, option := range cover {
+		if k.dl.optionLabels != nil {
+			k.w.WriteString(k.dl.optionLabels[option])
+			k.w.WriteByte('\n')
+			continue
+		}
+		separator := ""
+		for e := range k.dl.row(option) {
+			entry := &k.dl.entries[e]
+			k.w.WriteString(separator + k.dl.ItemLabel(int(entry.item)))
+			if entry.color != 0 {
+				fmt.Fprintf(k.w, ":%d", entry.color)
+			}
+			separator = " "
+		}
+		k.w.WriteByte('\n')
+	}
+	_, err := k.w.Write(nil)
+	return err
+}
+
+// Close prints the number of covers written, and flushes the output.
+func (k *KnuthWriter) Close() error {
+	plural := "s"
+	if k.count == 1 {
+		plural = ""
+	}
+	fmt.Fprintf(k.w, "Altogether %d solution%s.\n", k.count, plural)
+	return k.w.Flush()
+}
+
+// WriteKnuth prints every cover of the problem with a KnuthWriter,
+// listing each cover's options in the order the search selected them.
+func (dl *DLX) WriteKnuth(w io.Writer) error {
+	k := NewKnuthWriter(w, dl)
+	var err error
+	cover := []int{}
+	dl.GenerateSolutionsNoCopy(func(solution []Step) bool {
+		cover = cover[:0]
+		for _, step := range solution {
+			cover = append(cover, step.Option)
+		}
+		err = k.WriteCover(cover)
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := dl.searchErr(); err != nil {
+		return err
+	}
+	return k.Close()
+}
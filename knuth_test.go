@@ -0,0 +1,96 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseKnuth(t *testing.T) {
+	// The examples from TAOCP 7.2.2.1.
+	dl, err := ParseKnuth(strings.NewReader(`| exact cover
+a b c d e f g
+c e
+a d g
+b c f
+a d f
+
+b g
+d e g
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	covers := [][]string{}
+	dl.GenerateLabeledCovers(func(cover []string) bool {
+		covers = append(covers, cover)
+		return true
+	})
+	if want := [][]string{{"a d f", "b g", "c e"}}; !reflect.DeepEqual(covers, want) {
+		t.Errorf("should find %v, found %v", want, covers)
+	}
+
+	dl, err = ParseKnuth(strings.NewReader(`p q r | x y
+p q x y:A
+p r x:A y
+p x:B
+q x:A
+r y:B
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if covers := dl.AllCovers(); !reflect.DeepEqual(covers, [][]int{{3, 1}}) {
+		t.Errorf("should be [[3 1]], got %v", covers)
+	}
+	if label := dl.ItemLabel(4); label != "y" {
+		t.Errorf("item 4 should be y, got %q", label)
+	}
+
+	for _, input := range []string{
+		"",
+		"| nothing but comments\n",
+		"a b | c | d\n",
+		"a a\n",
+		"2:3|a\n",
+		"a | b\na c\n",
+		"a | b\na:A\n",
+		"a | b\na b:\n",
+		"a | b\na b a\n",
+	} {
+		if _, err := ParseKnuth(strings.NewReader(input)); err == nil {
+			t.Errorf("should reject %q", input)
+		}
+	}
+}
+
+func TestWriteKnuth(t *testing.T) {
+	dl, err := ParseKnuth(strings.NewReader(`p q r | x y
+p q x y:A
+p r x:A y
+p x:B
+q x:A
+r y:B
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &strings.Builder{}
+	if err := dl.WriteKnuth(b); err != nil {
+		t.Fatal(err)
+	}
+	if want := "q x:A\np r x:A y\nAltogether 1 solution.\n"; b.String() != want {
+		t.Errorf("should print %q, printed %q", want, b.String())
+	}
+
+	b.Reset()
+	k := NewKnuthWriter(b, NewColored(2, 1, [][]ColoredItem{{{0, 0}, {2, 3}}, {{1, 0}}}))
+	k.WriteCover([]int{0, 1})
+	k.WriteCover([]int{1})
+	if err := k.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if want := "0 2:3\n1\n\n1\nAltogether 2 solutions.\n"; b.String() != want {
+		t.Errorf("should print %q, printed %q", want, b.String())
+	}
+}
@@ -0,0 +1,79 @@
+package dancinglinks
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// A named option, listing the names of the items it covers.
+type LabeledOption struct {
+	Name  string
+	Items []string
+}
+
+// NewLabeled sets up an exact cover problem whose items and options
+// carry names, in the given order.  Item names must be distinct, and
+// options may only refer to known items.
+func NewLabeled(items []string, options []LabeledOption) (*DLX, error) {
+	indices := make(map[string]int, len(items))
+	for i, name := range items {
+		if _, ok := indices[name]; ok {
+			return nil, fmt.Errorf("dancinglinks: duplicate item %q", name)
+		}
+		indices[name] = i
+	}
+
+	plain := make([][]int, len(options))
+	optionLabels := make([]string, len(options))
+	for i, option := range options {
+		optionLabels[i] = option.Name
+		plain[i] = make([]int, len(option.Items))
+		for j, name := range option.Items {
+			index, ok := indices[name]
+			if !ok {
+				return nil, fmt.Errorf("dancinglinks: option %q covers unknown item %q", option.Name, name)
+			}
+			plain[i][j] = index
+		}
+	}
+
+	dl := New(len(items), plain)
+	dl.itemLabels = append([]string{}, items...)
+	dl.optionLabels = optionLabels
+	return dl, nil
+}
+
+// ItemLabel returns the name of an item, which for unlabeled problems
+// is its index.
+func (dl *DLX) ItemLabel(item int) string {
+	if dl.itemLabels == nil {
+		return strconv.Itoa(item)
+	}
+	return dl.itemLabels[item]
+}
+
+// OptionLabel returns the name of an option, which for unlabeled
+// problems is its index.
+func (dl *DLX) OptionLabel(option int) string {
+	if dl.optionLabels == nil {
+		return strconv.Itoa(option)
+	}
+	return dl.optionLabels[option]
+}
+
+// LabelCover translates the option indices of a cover to their names.
+func (dl *DLX) LabelCover(cover []int) []string {
+	labels := make([]string, len(cover))
+	for i, option := range cover {
+		labels[i] = dl.OptionLabel(option)
+	}
+	return labels
+}
+
+// GenerateLabeledCovers is like GenerateCovers, but yields the names
+// of the selected options.
+func (dl *DLX) GenerateLabeledCovers(yield func([]string) bool) {
+	dl.GenerateCovers(func(cover []int) bool {
+		return yield(dl.LabelCover(cover))
+	})
+}
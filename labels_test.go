@@ -0,0 +1,66 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+// The sushi example from the README.
+var sushiItems = []string{
+	"hibachi", "albacore", "salmon", "yellowtail", "tuna", "shrimp", "egg",
+}
+
+var sushiOptions = []LabeledOption{
+	{"chef's choice", []string{"salmon", "tuna"}},
+	{"deluxe platter", []string{"hibachi", "yellowtail", "egg"}},
+	{"family favorites", []string{"albacore", "salmon", "shrimp"}},
+	{"local specials", []string{"hibachi", "yellowtail", "shrimp"}},
+	{"catch of the day", []string{"albacore", "egg"}},
+	{"party platter", []string{"yellowtail", "tuna", "egg"}},
+}
+
+func TestNewLabeled(t *testing.T) {
+	dl, err := NewLabeled(sushiItems, sushiOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	covers := [][]string{}
+	dl.GenerateLabeledCovers(func(cover []string) bool {
+		covers = append(covers, cover)
+		return true
+	})
+	expected := [][]string{{"local specials", "catch of the day", "chef's choice"}}
+	if !reflect.DeepEqual(covers, expected) {
+		t.Errorf("should be %q, got %q", expected, covers)
+	}
+
+	if label := dl.ItemLabel(dl.AnySolution()[0].Item); label != "hibachi" {
+		t.Errorf("should first cover hibachi, got %q", label)
+	}
+	if label := classic.toDLX().OptionLabel(3); label != "3" {
+		t.Errorf("unlabeled options should be labeled by index, got %q", label)
+	}
+
+	if _, err := NewLabeled([]string{"a", "a"}, nil); err == nil {
+		t.Errorf("should reject duplicate items")
+	}
+	if _, err := NewLabeled([]string{"a"}, []LabeledOption{{"x", []string{"b"}}}); err == nil {
+		t.Errorf("should reject unknown items")
+	}
+}
+
+func TestLabeledSteps(t *testing.T) {
+	dl, err := NewLabeled(sushiItems, sushiOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testExample(t, dl.AllSolutions(), [][]Step{
+		[]Step{
+			Step{0, 3, []int{1, 3}, 0, 7, "hibachi", "local specials"},
+			Step{1, 4, []int{4}, 1, 4, "albacore", "catch of the day"},
+			Step{2, 0, []int{0}, 2, 2, "salmon", "chef's choice"},
+		},
+	})
+}
@@ -0,0 +1,164 @@
+package dancinglinks
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNodeLimit is reported by Err when a search was cut short
+	// after visiting as many search nodes as allowed by SetNodeLimit.
+	ErrNodeLimit = errors.New("dancinglinks: node limit exceeded")
+
+	// ErrTimeout is reported by Err when a search was cut short upon
+	// reaching the deadline set by SetDeadline.
+	ErrTimeout = errors.New("dancinglinks: deadline exceeded")
+)
+
+// How many search nodes to visit between looking at the clock or the
+// context.
+const checkInterval = 256
+
+// SetNodeLimit limits each search to visiting at most n search nodes,
+// i.e. selecting at most n options in total while backtracking.  A
+// search exceeding the limit stops as if its caller had stopped it,
+// and Err then returns ErrNodeLimit.  Zero means no limit.
+func (dl *DLX) SetNodeLimit(n int) {
+	dl.nodeLimit = n
+}
+
+// SetDepthLimit limits enumerating and counting solutions to those
+// selecting at most n options, not counting forced options.  Zero
+// means no limit.
+func (dl *DLX) SetDepthLimit(n int) {
+	dl.depthLimit = n
+}
+
+// GenerateSolutionsByDepth enumerates solutions by iterative
+// deepening: it searches with increasing depth limits, yielding the
+// solutions selecting exactly n options in the n-th round, up to
+// maxDepth options (or without bound if maxDepth is zero).  Solutions
+// thus come in order of increasing size, and small solutions are found
+// without exploring the deeper parts of the search tree.  Like
+// GenerateSolutions, it returns false if stopped early.
+func (dl *DLX) GenerateSolutionsByDepth(maxDepth int, yield func([]Step) bool) bool {
+	defer dl.SetDepthLimit(dl.depthLimit)
+
+	for depth := 1; maxDepth == 0 || depth <= maxDepth; depth++ {
+		dl.SetDepthLimit(depth)
+		keepGoing := dl.GenerateSolutions(func(solution []Step) bool {
+			// Nonempty solutions of smaller size have been yielded in
+			// previous rounds already.
+			if len(solution) > 0 && len(solution) < depth {
+				return true
+			}
+			return yield(solution)
+		})
+		if !keepGoing {
+			return false
+		}
+
+		// Without any part of the search tree cut off, deeper searches
+		// would find nothing new.
+		if !dl.depthCutoff {
+			return true
+		}
+	}
+	return true
+}
+
+// SetDeadline makes searches stop once the given time has passed, in
+// which case Err returns ErrTimeout.  The zero time means no deadline.
+func (dl *DLX) SetDeadline(t time.Time) {
+	dl.deadline = t
+}
+
+// AnySolutionTimeout is like AnySolution, but gives up after the given
+// duration, returning ErrTimeout.  A nil solution with a nil error
+// means there is no solution.
+func (dl *DLX) AnySolutionTimeout(d time.Duration) ([]Step, error) {
+	defer dl.SetDeadline(dl.deadline)
+	dl.SetDeadline(time.Now().Add(d))
+
+	solution := dl.AnySolution()
+	if err := dl.searchErr(); err != nil {
+		return nil, err
+	}
+	return solution, nil
+}
+
+// AnyCoverTimeout is like AnyCover, but gives up after the given
+// duration, returning ErrTimeout.  A nil cover with a nil error means
+// there is no cover.
+func (dl *DLX) AnyCoverTimeout(d time.Duration) ([]int, error) {
+	solution, err := dl.AnySolutionTimeout(d)
+	if solution == nil {
+		return nil, err
+	}
+	return coverOf(solution), nil
+}
+
+// Resets the bookkeeping before starting a new search.
+func (dl *DLX) resetSearch() {
+	dl.err = nil
+	dl.nodes = 0
+	dl.deadEnds = 0
+	dl.depthCutoff = false
+}
+
+// Statistics about a search.
+type SearchStats struct {
+	// The number of search nodes visited, as limited by SetNodeLimit.
+	Nodes int
+
+	// The number of moves that left some item without any choices,
+	// which the search took back right away instead of branching on
+	// the item.
+	DeadEnds int
+}
+
+// SearchStats reports statistics about the most recent search.
+func (dl *DLX) SearchStats() SearchStats {
+	return SearchStats{Nodes: dl.nodes, DeadEnds: dl.deadEnds}
+}
+
+// Accounts for visiting a search node, reporting whether the search
+// may go on.
+func (dl *DLX) visit() bool {
+	if dl.err != nil {
+		return false
+	}
+
+	dl.nodes++
+	if dl.nodeLimit > 0 && dl.nodes > dl.nodeLimit {
+		dl.err = ErrNodeLimit
+		return false
+	}
+
+	if dl.nodes%checkInterval != 1 {
+		return true
+	}
+
+	if !dl.deadline.IsZero() && time.Now().After(dl.deadline) {
+		dl.err = ErrTimeout
+		return false
+	}
+
+	if dl.ctx != nil {
+		if err := dl.ctx.Err(); err != nil {
+			dl.err = err
+			return false
+		}
+	}
+	return true
+}
+
+// The reason the most recent search was cut short, not counting
+// failing fast on uncoverable items, which merely means that there
+// are no solutions.
+func (dl *DLX) searchErr() error {
+	if _, ok := dl.err.(*UncoverableError); ok {
+		return nil
+	}
+	return dl.err
+}
@@ -0,0 +1,122 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNodeLimit(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	dl.SetNodeLimit(4)
+	if dl.GenerateSolutions(func([]Step) bool { return true }) {
+		t.Errorf("search should be cut short")
+	}
+	if dl.Err() != ErrNodeLimit {
+		t.Errorf("should report ErrNodeLimit, got %v", dl.Err())
+	}
+	if count := dl.CountSolutions(); dl.Err() != ErrNodeLimit || count.Int64() >= 4 {
+		t.Errorf("count should be cut short, got %v (err=%v)", count, dl.Err())
+	}
+
+	// Searches are limited individually, and the structure is restored
+	// after being cut short.
+	dl.SetNodeLimit(0)
+	testExample(t, dl.AllSolutions(), classicDuplicates.solution)
+	if dl.Err() != nil {
+		t.Errorf("should report no error, got %v", dl.Err())
+	}
+}
+
+func TestAnySolutionTimeout(t *testing.T) {
+	// Pigeonhole: 14 pigeons in 13 holes, which takes a long time to
+	// rule out.  Items 0-13 are the pigeons, and items 14-26 are
+	// (secondary) holes.
+	options := [][]ColoredItem{}
+	for pigeon := 0; pigeon < 14; pigeon++ {
+		for hole := 14; hole < 27; hole++ {
+			options = append(options, []ColoredItem{{pigeon, 0}, {hole, 0}})
+		}
+	}
+	dl := NewColored(14, 13, options)
+
+	solution, err := dl.AnySolutionTimeout(10 * time.Millisecond)
+	if solution != nil || err != ErrTimeout {
+		t.Errorf("should time out, got %v (err=%v)", solution, err)
+	}
+
+	solution, err = classic.toDLX().AnySolutionTimeout(time.Minute)
+	testExample(t, [][]Step{solution}, classic.solution)
+	if err != nil {
+		t.Errorf("should not time out, got %v", err)
+	}
+
+	solution, err = impossible.toDLX().AnySolutionTimeout(time.Minute)
+	if solution != nil || err != nil {
+		t.Errorf("should find no solution, got %v (err=%v)", solution, err)
+	}
+}
+
+func TestDepthLimit(t *testing.T) {
+	// Items 0-3 can be covered by four singletons, two pairs, or one
+	// option covering everything.
+	dl := New(4, [][]int{
+		[]int{0}, []int{1}, []int{2}, []int{3},
+		[]int{0, 1}, []int{2, 3},
+		[]int{0, 1, 2, 3},
+	})
+
+	dl.SetDepthLimit(2)
+	if count := len(dl.AllCovers()); count != 2 {
+		t.Errorf("should find 2 covers of at most 2 options, found %d", count)
+	}
+	if count := dl.CountSolutions().Int64(); count != 2 {
+		t.Errorf("should count 2 covers of at most 2 options, counted %d", count)
+	}
+	if count, _ := dl.CountSolutionsUpTo(5); count != 2 {
+		t.Errorf("should count 2 covers of at most 2 options up to 5, counted %d", count)
+	}
+	dl.SetDepthLimit(0)
+
+	sizes := []int{}
+	dl.GenerateSolutionsByDepth(0, func(solution []Step) bool {
+		sizes = append(sizes, len(solution))
+		return true
+	})
+	if !reflect.DeepEqual(sizes, []int{1, 2, 3, 3, 4}) {
+		t.Errorf("should find covers in order of size, found sizes %v", sizes)
+	}
+
+	sizes = []int{}
+	dl.GenerateSolutionsByDepth(2, func(solution []Step) bool {
+		sizes = append(sizes, len(solution))
+		return true
+	})
+	if !reflect.DeepEqual(sizes, []int{1, 2}) {
+		t.Errorf("should find covers of at most 2 options, found sizes %v", sizes)
+	}
+}
+
+func TestSearchStats(t *testing.T) {
+	// Whichever option covers item 0, it leaves item 2 (or 1) without
+	// any choices.
+	dl := New(3, [][]int{
+		[]int{0, 1},
+		[]int{0, 2},
+		[]int{1, 2},
+	})
+	if solutions := dl.AllSolutions(); len(solutions) != 0 {
+		t.Errorf("should find no solutions, found %v", solutions)
+	}
+	if stats := dl.SearchStats(); stats != (SearchStats{Nodes: 2, DeadEnds: 2}) {
+		t.Errorf("should visit 2 nodes, both dead ends, got %+v", stats)
+	}
+
+	// Statistics start over with each search, even one that fails
+	// fast.
+	dl.ForceOptions(0)
+	dl.AllSolutions()
+	if stats := dl.SearchStats(); stats != (SearchStats{}) {
+		t.Errorf("should visit nothing with item 2 uncoverable, got %+v", stats)
+	}
+}
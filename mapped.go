@@ -0,0 +1,48 @@
+//go:build unix
+
+package dancinglinks
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// NewMappedStream is like NewStream, but keeps the entries, which take
+// up the bulk of a DLX, in the given file, memory-mapped, so that
+// problems larger than memory can still be set up and searched, paged
+// in by the operating system as needed.  Each option's entries are
+// stored together, in the order the options are added.
+//
+// The file is resized to hold up to capacity entries, column headers
+// included, beyond which adding options panics.  The capacity must be
+// at least the number of items, and like any DLX it is limited to
+// 2^31-1 entries of 28 bytes each, so that the file holds at most
+// about 60 GB.  The DLX must no longer be used once the returned unmap
+// function is called.  Adding options to the sealed DLX itself moves
+// its entries back to memory.
+func NewMappedStream(file *os.File, primaryCount, secondaryCount, capacity int) (s *Stream, unmap func() error, err error) {
+	itemCount := primaryCount + secondaryCount
+	if capacity < itemCount {
+		return nil, nil, fmt.Errorf("dancinglinks: capacity %d cannot hold the headers of %d items", capacity, itemCount)
+	}
+	checkEntryCount(capacity, 0)
+
+	size := int(unsafe.Sizeof(entryNode{}))
+	if err := file.Truncate(int64(max(capacity, 1) * size)); err != nil {
+		return nil, nil, err
+	}
+	data, err := syscall.Mmap(int(file.Fd()), 0, max(capacity, 1)*size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The entries hold no pointers, so the garbage collector need not
+	// see into the mapping.
+	entries := unsafe.Slice((*entryNode)(unsafe.Pointer(&data[0])), max(capacity, 1))[:0:capacity]
+
+	dl := newDLX(primaryCount, secondaryCount, nil, nil)
+	dl.entries = append(entries, dl.entries...)
+	return &Stream{dl: dl, limit: capacity}, func() error { return syscall.Munmap(data) }, nil
+}
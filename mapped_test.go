@@ -0,0 +1,67 @@
+//go:build unix
+
+package dancinglinks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewMappedStream(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "entries"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	entryCount := classicDuplicates.itemCount
+	for _, option := range classicDuplicates.options {
+		entryCount += len(option)
+	}
+	s, unmap, err := NewMappedStream(file, classicDuplicates.itemCount, 0, entryCount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := unmap(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	for _, option := range classicDuplicates.options {
+		s.AddOption(option...)
+	}
+	dl := s.Seal()
+	if !dl.Equal(classicDuplicates.toDLX()) {
+		t.Errorf("should set up the same problem as New, got %v", dl)
+	}
+	testExample(t, dl.AllSolutions(), classicDuplicates.solution)
+
+	if info, err := file.Stat(); err != nil || info.Size() < int64(entryCount)*28 {
+		t.Errorf("file should hold %d entries, got %v, %v", entryCount, info, err)
+	}
+
+	if _, _, err := NewMappedStream(file, 3, 1, 3); err == nil {
+		t.Errorf("should reject a capacity below the number of items")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("should panic past capacity")
+			}
+		}()
+		small, err := os.Create(filepath.Join(t.TempDir(), "small"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer small.Close()
+		s, unmap, err := NewMappedStream(small, 2, 0, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer unmap()
+		s.AddOption(0, 1)
+	}()
+}
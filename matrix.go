@@ -0,0 +1,52 @@
+package dancinglinks
+
+// Which matrix ToMatrixState renders.
+type MatrixState int
+
+const (
+	// The original matrix, with a row for every option and a column
+	// for every item, ignoring forced options.
+	MatrixOriginal MatrixState = iota
+
+	// The matrix left after forcing options, with a row for each
+	// option in ActiveOptions and a column for each item in
+	// ActiveItems, in the same order.
+	MatrixReduced
+)
+
+// ToMatrixState renders the exact cover matrix in the given state.
+func (dl *DLX) ToMatrixState(state MatrixState) [][]bool {
+	if state == MatrixOriginal {
+		mat := make([][]bool, len(dl.options))
+		for i := range dl.options {
+			mat[i] = make([]bool, len(dl.items))
+			for e := range dl.row(i) {
+				entry := &dl.entries[e]
+				mat[i][entry.item] = true
+			}
+		}
+		return mat
+	}
+
+	items := dl.ActiveItems()
+	columns := make([]int, len(dl.items))
+	for i := range columns {
+		columns[i] = -1
+	}
+	for column, item := range items {
+		columns[item] = column
+	}
+
+	options := dl.ActiveOptions()
+	mat := make([][]bool, len(options))
+	for i, option := range options {
+		mat[i] = make([]bool, len(items))
+		for e := range dl.row(option) {
+			entry := &dl.entries[e]
+			if column := columns[entry.item]; column >= 0 {
+				mat[i][column] = true
+			}
+		}
+	}
+	return mat
+}
@@ -0,0 +1,39 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToMatrixState(t *testing.T) {
+	dl := classic.toDLX()
+	dl.ForceOptions(0)
+
+	if mat := dl.ToMatrixState(MatrixOriginal); !reflect.DeepEqual(mat, classic.matrix) {
+		t.Errorf(
+			"original matrix mismatch:\nshould be\n%s\ngot\n%s",
+			sprintMatrix(classic.matrix), sprintMatrix(mat),
+		)
+	}
+
+	// Options 1, 3, and 4 remain, over items 0, 1, 3, 5, and 6.
+	reduced := [][]bool{
+		[]bool{true, false, true, false, true},
+		[]bool{true, false, true, true, false},
+		[]bool{false, true, false, false, true},
+	}
+	if mat := dl.ToMatrixState(MatrixReduced); !reflect.DeepEqual(mat, reduced) {
+		t.Errorf(
+			"reduced matrix mismatch:\nshould be\n%s\ngot\n%s",
+			sprintMatrix(reduced), sprintMatrix(mat),
+		)
+	}
+
+	dl.UnforceOptions()
+	if mat := dl.ToMatrixState(MatrixReduced); !reflect.DeepEqual(mat, classic.matrix) {
+		t.Errorf(
+			"reduced matrix without forcing mismatch:\nshould be\n%s\ngot\n%s",
+			sprintMatrix(classic.matrix), sprintMatrix(mat),
+		)
+	}
+}
@@ -0,0 +1,173 @@
+package dancinglinks
+
+import (
+	"sort"
+	"strconv"
+)
+
+// AddOption appends a new option covering the given items and returns
+// its index.  Forced options stay forced, and rule out the new option
+// if they conflict with it.  Options may not be added during a search.
+func (dl *DLX) AddOption(items []int) int {
+	// Entries can only be spliced into intact columns.
+	resume := dl.suspend()
+
+	index := len(dl.options)
+	dl.options = append(dl.options, -1)
+	dl.conflicts = nil
+	for _, item := range items {
+		dl.appendEntry(item, index)
+	}
+	dl.hidden = append(dl.hidden, 0)
+
+	if dl.optionLabels != nil {
+		dl.optionLabels = append(dl.optionLabels, strconv.Itoa(index))
+	}
+	if dl.symmetryPrev != nil {
+		dl.symmetryPrev = append(dl.symmetryPrev, -1)
+	}
+
+	resume()
+	return index
+}
+
+// AddItem appends a new primary item and returns its index.  The
+// given existing options are extended to cover it as well; if none
+// are given, no option covers the new item until one is added.  As
+// with AddOption, forced options stay forced, and items may not be
+// added during a search.
+func (dl *DLX) AddItem(options ...int) int {
+	resume := dl.suspend()
+	dl.conflicts = nil
+
+	index := len(dl.items)
+	head := len(dl.entries)
+	checkEntryCount(len(dl.entries)+1, len(dl.options))
+	dl.entries = append(dl.entries, entryNode{item: int32(index), option: -1, up: int32(head), down: int32(head)})
+	dl.items = append(dl.items, itemNode{
+		head:     head,
+		purifier: -1,
+		left:     dl.itemHead.left,
+		right:    root,
+	})
+	dl.item(dl.itemHead.left).right = index
+	dl.itemHead.left = index
+	dl.uncovered++
+
+	// Keep the column in option order, like the others.
+	options = append([]int{}, options...)
+	sort.Ints(options)
+	for _, option := range options {
+		dl.appendEntry(index, option)
+	}
+
+	if dl.itemLabels != nil {
+		dl.itemLabels = append(dl.itemLabels, strconv.Itoa(index))
+	}
+
+	resume()
+	return index
+}
+
+// DisableOption excludes an option from the search, as if it were
+// absent, until it is enabled again.  Unlike unforcing, enabling and
+// disabling may happen in any order.  Disabling a forced option does
+// not retract it, but keeps it out once it is retracted.
+func (dl *DLX) DisableOption(index int) {
+	if intSliceContains(dl.disabled, index) {
+		return
+	}
+	resume := dl.suspend()
+	dl.disabled = append(dl.disabled, index)
+	resume()
+}
+
+// EnableOption makes a disabled option available again.  Options that
+// are not disabled are left alone.
+func (dl *DLX) EnableOption(index int) {
+	if !intSliceContains(dl.disabled, index) {
+		return
+	}
+	resume := dl.suspend()
+	for i, option := range dl.disabled {
+		if option == index {
+			dl.disabled = append(dl.disabled[:i], dl.disabled[i+1:]...)
+			break
+		}
+	}
+	resume()
+}
+
+// Appends an entry for the given option to the bottom of an item's
+// column and the end of the option's row.
+func (dl *DLX) appendEntry(item int, option int) {
+	index := len(dl.entries)
+	checkEntryCount(index+1, option+1)
+	head := dl.items[item].head
+	entry := entryNode{
+		item:   int32(item),
+		option: int32(option),
+		up:     dl.entries[head].up,
+		down:   int32(head),
+		left:   int32(index),
+		right:  int32(index),
+	}
+	if first := dl.options[option]; first != -1 {
+		entry.left = dl.entries[first].left
+		entry.right = first
+	} else {
+		dl.options[option] = int32(index)
+	}
+	dl.entries = append(dl.entries, entry)
+
+	dl.entries[entry.up].down = int32(index)
+	dl.entries[head].up = int32(index)
+	dl.entries[entry.left].right = int32(index)
+	dl.entries[entry.right].left = int32(index)
+	dl.items[item].choices++
+}
+
+// Retracts all forced options and restores all disabled ones, so that
+// every column is intact, and returns a function that disables and
+// forces them again.
+func (dl *DLX) suspend() func() {
+	forced := dl.retract()
+	return func() {
+		dl.reapply(forced)
+	}
+}
+
+// Retracts all forced options and restores all disabled ones, and
+// returns the options that were forced.  A disabled option that is
+// forced stays forced, and is only deleted once it is retracted.
+func (dl *DLX) retract() []int {
+	forced := append([]int{}, dl.selected...)
+	for i := len(forced) - 1; i >= 0; i-- {
+		dl.unchooseOption(forced[i])
+	}
+	dl.selected = dl.selected[:0]
+	for i := len(dl.disabled) - 1; i >= 0; i-- {
+		if !intSliceContains(forced, dl.disabled[i]) {
+			dl.restoreOption(dl.disabled[i])
+		}
+	}
+
+	// The buckets of items and the count of starved items do not
+	// follow the changes.
+	dl.buckets = nil
+	dl.starvedExact = false
+
+	return forced
+}
+
+// Deletes the disabled options and forces the given ones, starting
+// from intact columns.  Disabled options among the forced ones are
+// forced rather than deleted.
+func (dl *DLX) reapply(forced []int) {
+	for _, option := range dl.disabled {
+		if !intSliceContains(forced, option) {
+			dl.deleteOption(option)
+		}
+	}
+	dl.ForceOptions(forced...)
+}
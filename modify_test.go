@@ -0,0 +1,155 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddOption(t *testing.T) {
+	dl := New(7, [][]int{
+		[]int{2, 4},
+		[]int{0, 3, 6},
+		[]int{1, 2, 5},
+		[]int{1, 6},
+		[]int{3, 4, 6},
+	})
+	if covers := dl.AllCovers(); len(covers) != 0 {
+		t.Errorf("expected no covers before adding, got %v", covers)
+	}
+
+	dl.ForceOptions(0)
+	if index := dl.AddOption([]int{0, 3, 5}); index != 5 {
+		t.Errorf("expected new option 5, got %d", index)
+	}
+
+	covers := dl.AllCovers()
+	sortSequences(covers)
+	if !reflect.DeepEqual(covers, [][]int{[]int{3, 5}}) {
+		t.Errorf("expected covers [[3 5]] with option 0 forced, got %v", covers)
+	}
+
+	// A new option conflicting with a forced one is ruled out until
+	// the forced option is retracted.
+	dl.AddOption([]int{0, 2, 3, 5})
+	if dl.optionLive(6) {
+		t.Errorf("expected option 6 to conflict with forced option 0")
+	}
+	dl.UnforceOptions()
+	covers = dl.AllCovers()
+	sortSequences(covers)
+	if !reflect.DeepEqual(covers, [][]int{[]int{0, 3, 5}}) {
+		t.Errorf("expected covers [[0 3 5]], got %v", covers)
+	}
+	if !dl.optionLive(6) {
+		t.Errorf("expected option 6 to be available after unforcing")
+	}
+}
+
+func TestAddItem(t *testing.T) {
+	dl := classic.toDLX()
+	dl.ForceOptions(4)
+
+	// Only the classic solution's option 3 covers the new item, and
+	// the only other option covering it is ruled out.
+	if index := dl.AddItem(3, 5); index != 7 {
+		t.Errorf("expected new item 7, got %d", index)
+	}
+	if choices := dl.RemainingChoices(7); !reflect.DeepEqual(choices, []int{3}) {
+		t.Errorf("expected remaining choices [3] for item 7, got %v", choices)
+	}
+	if option := dl.Option(3); !reflect.DeepEqual(option, []int{0, 3, 5, 7}) {
+		t.Errorf("expected option 3 to be [0 3 5 7], got %v", option)
+	}
+
+	covers := dl.AllCovers()
+	sortSequences(covers)
+	if !reflect.DeepEqual(covers, [][]int{[]int{0, 3}}) {
+		t.Errorf("expected covers [[0 3]] with option 4 forced, got %v", covers)
+	}
+
+	// An item no option covers makes the problem infeasible.
+	dl.AddItem()
+	if covers := dl.AllCovers(); len(covers) != 0 {
+		t.Errorf("expected no covers with an uncoverable item, got %v", covers)
+	}
+}
+
+func TestDisableOption(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	dl.ForceOptions(6)
+	dl.DisableOption(0)
+	dl.DisableOption(4)
+
+	covers := dl.AllCovers()
+	sortSequences(covers)
+	if !reflect.DeepEqual(covers, [][]int{[]int{1, 5}}) {
+		t.Errorf("expected covers [[1 5]], got %v", covers)
+	}
+	if options := dl.ActiveOptions(); !reflect.DeepEqual(options, []int{1, 5}) {
+		t.Errorf("expected active options [1 5], got %v", options)
+	}
+
+	// Enabling needn't happen in reverse order.
+	dl.EnableOption(0)
+	dl.UnforceOptions()
+	covers = dl.AllCovers()
+	sortSequences(covers)
+	if !reflect.DeepEqual(covers, [][]int{[]int{0, 5, 6}, []int{1, 5, 6}}) {
+		t.Errorf("expected covers [[0 5 6] [1 5 6]], got %v", covers)
+	}
+
+	dl.EnableOption(4)
+	testExample(t, dl.AllSolutions(), classicDuplicates.solution)
+
+	dl.DisableOption(7)
+	dl.Reset()
+	testExample(t, dl.AllSolutions(), classicDuplicates.solution)
+}
+
+func TestForceDisabledOption(t *testing.T) {
+	dl := classic.toDLX()
+	dl.DisableOption(3)
+	if err := dl.ForceOptionsChecked(3); err == nil {
+		t.Errorf("expected an error forcing a disabled option")
+	}
+	if len(dl.selected) != 0 {
+		t.Errorf("expected nothing forced, got %v", dl.selected)
+	}
+}
+
+func TestDisableForcedOption(t *testing.T) {
+	dl := New(2, [][]int{{0}, {1}, {0, 1}})
+	dl.ForceOptions(0)
+	dl.DisableOption(0)
+	if selected := dl.Selected(); !reflect.DeepEqual(selected, []int{0}) {
+		t.Errorf("should keep option 0 forced, got %v", selected)
+	}
+	if covers := dl.AllCovers(); !reflect.DeepEqual(covers, [][]int{{1}}) {
+		t.Errorf("should be [[1]], got %v", covers)
+	}
+
+	// Once retracted, the option stays disabled.
+	dl.UnforceOptions()
+	if covers := dl.AllCovers(); !reflect.DeepEqual(covers, [][]int{{2}}) {
+		t.Errorf("should be [[2]], got %v", covers)
+	}
+	dl.EnableOption(0)
+	if !dl.Equal(New(2, [][]int{{0}, {1}, {0, 1}})) {
+		t.Errorf("should be restored, got %v", dl)
+	}
+	if covers := dl.AllCovers(); len(covers) != 2 {
+		t.Errorf("should find 2 covers, got %v", covers)
+	}
+
+	// Likewise when options forced after it stay forced.
+	dl = New(3, [][]int{{0}, {1}, {0, 1}, {2}})
+	dl.ForceOptions(3, 0)
+	dl.DisableOption(0)
+	dl.UnforceOption(0)
+	if selected := dl.Selected(); !reflect.DeepEqual(selected, []int{3}) {
+		t.Errorf("should keep option 3 forced, got %v", selected)
+	}
+	if covers := dl.AllCovers(); !reflect.DeepEqual(covers, [][]int{{2}}) {
+		t.Errorf("should be [[2]], got %v", covers)
+	}
+}
@@ -0,0 +1,49 @@
+package dancinglinks
+
+// GenerateSolutionsNoCopy is like GenerateSolutions, but avoids
+// copying each solution by yielding the solver's own search path.  The
+// yielded slice is only valid until yield returns, and must not be
+// modified or retained; copy it to keep it.
+func (dl *DLX) GenerateSolutionsNoCopy(yield func([]Step) bool) bool {
+	search := dl.NewSearch()
+	for {
+		solution, ok := search.next(false)
+		if !ok {
+			return dl.searchErr() == nil
+		}
+
+		if !yield(solution) {
+			search.Close()
+			return false
+		}
+	}
+}
+
+// GenerateCoversNoCopy is like GenerateCovers, but yields the same
+// buffer for every cover.  The yielded slice is only valid until yield
+// returns, and must not be retained; copy it to keep it.
+func (dl *DLX) GenerateCoversNoCopy(yield func([]int) bool) {
+	dl.GenerateCoversInto(nil, yield)
+}
+
+// GenerateCoversInto is like GenerateCoversNoCopy, but yields covers
+// in the given buffer, so that repeated searches need not allocate
+// one.  A buffer too small for some cover is grown, after which the
+// yielded slice no longer shares its storage.
+func (dl *DLX) GenerateCoversInto(buf []int, yield func([]int) bool) {
+	copied := func(cover []int) bool {
+		buf = append(buf[:0], cover...)
+		return yield(buf)
+	}
+	if dl.searchCovers(copied) {
+		return
+	}
+
+	dl.GenerateSolutionsNoCopy(func(solution []Step) bool {
+		buf = buf[:0]
+		for _, step := range solution {
+			buf = append(buf, step.Option)
+		}
+		return yield(buf)
+	})
+}
@@ -0,0 +1,73 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerateNoCopy(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+
+	solutions := [][]Step{}
+	dl.GenerateSolutionsNoCopy(func(solution []Step) bool {
+		solutions = append(solutions, append([]Step{}, solution...))
+		return true
+	})
+	testExample(t, solutions, classicDuplicates.solution)
+
+	covers := [][]int{}
+	dl.GenerateCoversNoCopy(func(cover []int) bool {
+		covers = append(covers, append([]int{}, cover...))
+		return true
+	})
+	if !reflect.DeepEqual(covers, dl.AllCovers()) {
+		t.Errorf("should be %v, got %v", dl.AllCovers(), covers)
+	}
+
+	// Stopping early leaves the DLX intact.
+	dl.GenerateCoversNoCopy(func([]int) bool { return false })
+	testExample(t, dl.AllSolutions(), classicDuplicates.solution)
+}
+
+func TestGenerateCoversInto(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+
+	buf := make([]int, 0, 8)
+	covers := [][]int{}
+	dl.GenerateCoversInto(buf, func(cover []int) bool {
+		if &cover[0] != &buf[:1][0] {
+			t.Errorf("should yield covers in the given buffer")
+		}
+		covers = append(covers, append([]int{}, cover...))
+		return true
+	})
+	if !reflect.DeepEqual(covers, dl.AllCovers()) {
+		t.Errorf("should be %v, got %v", dl.AllCovers(), covers)
+	}
+
+	// Each of the 10 items is covered by two duplicate singletons, for
+	// 1024 covers, which GenerateCovers allocates one by one but
+	// GenerateCoversInto does not.
+	options := [][]int{}
+	for item := 0; item < 10; item++ {
+		options = append(options, []int{item}, []int{item})
+	}
+	dl = New(10, options)
+	buf = make([]int, 0, 10)
+	copied := testing.AllocsPerRun(10, func() {
+		dl.GenerateCovers(func([]int) bool { return true })
+	})
+	reused := testing.AllocsPerRun(10, func() {
+		dl.GenerateCoversInto(buf, func([]int) bool { return true })
+	})
+	if copied-reused < 1024 {
+		t.Errorf("yielding covers should not allocate, allocated %v times instead of %v", reused, copied)
+	}
+}
+
+func BenchmarkGenerateCoversNoCopy(b *testing.B) {
+	dl := classicDuplicates.toDLX()
+	for i := 0; i < b.N; i++ {
+		dl.GenerateCoversNoCopy(func([]int) bool { return true })
+	}
+}
@@ -0,0 +1,172 @@
+package dancinglinks
+
+import (
+	"math"
+	"sort"
+)
+
+// SetCosts assigns a cost to each option, indexed by option index,
+// for use by MinCostCover.  Costs must be non-negative; options
+// without an assigned cost cost nothing.
+func (dl *DLX) SetCosts(costs []int) {
+	dl.costs = append([]int{}, costs...)
+}
+
+// SetPenalty marks an item as soft: the optimizing solvers
+// MinCostCover and MinPenaltyCover may leave it uncovered, at the
+// given (non-negative) penalty.
+func (dl *DLX) SetPenalty(item int, penalty int) {
+	dl.items[item].soft = true
+	dl.items[item].penalty = penalty
+}
+
+// MinCostCover returns an exact cover of minimum total cost, along
+// with that cost.  Soft items left uncovered add their penalty to the
+// cost.  As with GenerateCovers, forced options are not part of the
+// returned cover and do not count towards its cost.  If there is no
+// exact cover, ok is false.  If the node limit is exceeded, the result
+// is the best cover found so far, and Err returns ErrNodeLimit.
+func (dl *DLX) MinCostCover() (cover []int, cost int, ok bool) {
+	return dl.minCover(dl.cost, true)
+}
+
+// MinPenaltyCover returns a cover minimizing the total penalty of
+// the soft items it leaves uncovered, along with that penalty.  Every
+// other item is covered exactly once.  If there is no such cover, ok
+// is false.
+func (dl *DLX) MinPenaltyCover() (cover []int, penalty int, ok bool) {
+	return dl.minCover(func(int) int { return 0 }, true)
+}
+
+// FewestOptionsCover returns an exact cover using as few options as
+// possible, treating soft items as ordinary items.  Forced options
+// are not part of the returned cover.  If there is no exact cover, ok
+// is false.
+func (dl *DLX) FewestOptionsCover() (cover []int, ok bool) {
+	cover, _, ok = dl.minCover(func(int) int { return 1 }, false)
+	return cover, ok
+}
+
+func (dl *DLX) cost(option int) int {
+	if option < len(dl.costs) {
+		return dl.costs[option]
+	}
+	return 0
+}
+
+// Branch-and-bound search for a cover minimizing the total cost,
+// according to the given (non-negative) cost function.  If soft is
+// set, soft items may be left uncovered at their penalty.
+func (dl *DLX) minCover(cost func(int) int, soft bool) ([]int, int, bool) {
+	dl.resetSearch()
+
+	best := []int{}
+	bestCost := 0
+	found := false
+
+	path := []int{}
+	var search func(total int)
+	search = func(total int) {
+		if !dl.visit() {
+			return
+		}
+
+		// Give up on this branch if it cannot possibly beat the best
+		// cover found so far.
+		if found && total+dl.costBound(cost, soft) >= bestCost {
+			return
+		}
+
+		index, choices := dl.nextChoices([]int{})
+		if choices == nil {
+			best = append(best[:0], path...)
+			bestCost = total
+			found = true
+			return
+		}
+
+		// Try cheaper options first, so that good covers (and hence
+		// tight bounds) are found early.
+		sort.SliceStable(choices, func(i, j int) bool {
+			return cost(choices[i]) < cost(choices[j])
+		})
+
+		for _, option := range choices {
+			dl.chooseOption(option)
+			path = append(path, option)
+
+			search(total + cost(option))
+
+			path = path[:len(path)-1]
+			dl.unchooseOption(option)
+		}
+
+		// Alternatively, leave a soft item uncovered.
+		if item := &dl.items[index]; soft && item.soft {
+			// Covering the item without selecting any option hides
+			// all the options covering it.
+			dl.cover(index)
+			search(total + item.penalty)
+			dl.uncover(index)
+		}
+	}
+	search(0)
+
+	if !found {
+		return nil, 0, false
+	}
+	return best, bestCost, true
+}
+
+// A lower bound on the additional cost needed to cover the remaining
+// items.  Every remaining item must be covered by some option, which
+// costs at least as much as the cheapest option still covering it.
+// Moreover, spreading each option's cost evenly over the items it
+// covers, every remaining item costs at least its cheapest share.  A
+// soft item costs at most its penalty.
+func (dl *DLX) costBound(cost func(int) int, soft bool) int {
+	maxCheapest := 0
+	shares := 0.0
+	for index := dl.itemHead.right; index != root; index = dl.items[index].right {
+		item := &dl.items[index]
+		cheapest := -1
+		cheapestShare := math.Inf(1)
+		for entry := int(dl.entries[item.head].down); entry != item.head; entry = int(dl.entries[entry].down) {
+			option := int(dl.entries[entry].option)
+			c := cost(option)
+			if cheapest == -1 || c < cheapest {
+				cheapest = c
+			}
+			if share := float64(c) / float64(dl.primarySize(option)); share < cheapestShare {
+				cheapestShare = share
+			}
+		}
+		if soft && item.soft {
+			if cheapest == -1 || item.penalty < cheapest {
+				cheapest = item.penalty
+			}
+			cheapestShare = math.Min(cheapestShare, float64(item.penalty))
+		}
+		if cheapest > maxCheapest {
+			maxCheapest = cheapest
+		}
+		shares += cheapestShare
+	}
+
+	// Allow for rounding error when summing up fractional shares.
+	if bound := int(math.Ceil(shares - 1e-9)); bound > maxCheapest {
+		return bound
+	}
+	return maxCheapest
+}
+
+// The number of primary items in an option.
+func (dl *DLX) primarySize(option int) int {
+	size := 0
+	for entry := range dl.row(option) {
+		if !dl.items[dl.entries[entry].item].secondary {
+			size++
+		}
+	}
+	return size
+}
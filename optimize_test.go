@@ -0,0 +1,51 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMinCostCover(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	dl.SetCosts([]int{5, 1, 0, 0, 3, 2, 0, 0})
+	cover, cost, ok := dl.MinCostCover()
+	if !ok || cost != 3 || !reflect.DeepEqual(cover, []int{6, 5, 1}) {
+		t.Errorf("should be [6 5 1] with cost 3, got %v with cost %d (ok=%v)", cover, cost, ok)
+	}
+
+	if _, _, ok := impossible.toDLX().MinCostCover(); ok {
+		t.Errorf("impossible example should have no cover")
+	}
+}
+
+func TestFewestOptionsCover(t *testing.T) {
+	// Items 0-3 can be covered by four singletons, two pairs, or one
+	// option covering everything.
+	dl := New(4, [][]int{
+		[]int{0}, []int{1}, []int{2}, []int{3},
+		[]int{0, 1}, []int{2, 3},
+		[]int{0, 1, 2, 3},
+	})
+	cover, ok := dl.FewestOptionsCover()
+	if !ok || !reflect.DeepEqual(cover, []int{6}) {
+		t.Errorf("should be [6], got %v (ok=%v)", cover, ok)
+	}
+
+	cover, ok = trivial.toDLX().FewestOptionsCover()
+	if !ok || len(cover) != 0 {
+		t.Errorf("trivial example should have the empty cover, got %v (ok=%v)", cover, ok)
+	}
+}
+
+func TestMinPenaltyCover(t *testing.T) {
+	dl := impossible.toDLX()
+	dl.SetPenalty(0, 5)
+	dl.SetPenalty(2, 3)
+	cover, penalty, ok := dl.MinPenaltyCover()
+	if !ok || penalty != 3 || !reflect.DeepEqual(cover, []int{0}) {
+		t.Errorf("should be [0] with penalty 3, got %v with penalty %d (ok=%v)", cover, penalty, ok)
+	}
+
+	// Soft items are ordinary items as far as enumeration goes.
+	testExample(t, dl.AllSolutions(), impossible.solution)
+}
@@ -0,0 +1,108 @@
+package dancinglinks
+
+// The order in which the solver enumerates solutions.
+type Order int
+
+const (
+	// Branch on the item with the fewest remaining choices, as in
+	// Knuth's algorithm.  This is the default, and usually by far the
+	// fastest.
+	OrderFewestChoices Order = iota
+
+	// Enumerate solutions in lexicographic order of their (sorted)
+	// option indices, regardless of how constrained the items are.
+	// Each step covers the first item of the selected option.
+	OrderLexicographic
+
+	// Enumerate solutions in reverse lexicographic order, starting
+	// with the lexicographically largest one.
+	OrderReverseLexicographic
+
+	// Branch on the items in a fixed order, each step covering the
+	// first item left to cover, by the order set with SetItemOrder or
+	// else by index, however many choices it has left.  This spares
+	// the scan for the most constrained item at every node, and on
+	// regular problems such as sudoku, where a good order is known
+	// beforehand, makes for faster searches and a predictable order of
+	// solutions.
+	OrderStatic
+)
+
+// SetOrder sets the order in which solutions are enumerated.
+func (dl *DLX) SetOrder(order Order) {
+	dl.order = order
+}
+
+// Determines how to branch next, after a move involving the given
+// option (or -1 at the root), filling in the stage's item to cover,
+// the options still covering it, and the moves to try, reusing the
+// stage's buffers.  It reports false if there is nothing left to
+// cover; otherwise, the moves are empty if we have hit a dead end.
+func (dl *DLX) branch(after int, st *stage) bool {
+	if dl.order == OrderFewestChoices || dl.order == OrderStatic {
+		st.item, st.options = dl.nextChoices(st.options)
+		st.choices = st.options
+		return st.item != -1
+	}
+
+	// Lexicographic order: each option in turn is either part of the
+	// solution or not, and solutions containing it come first (or, in
+	// reverse, last).
+	st.options = st.options[:0]
+	st.choices = st.moves[:0]
+	st.item = dl.nextItem()
+	switch {
+	case st.item == root:
+		st.item = -1
+		return false
+	case dl.items[st.item].choices == 0:
+		return true
+	}
+
+	// Options up to the last one considered have been decided already,
+	// so the next one to decide is the first one still available.
+	for option := after + 1; option < len(dl.options); option++ {
+		if !dl.optionLive(option) {
+			continue
+		}
+
+		for entry := range dl.row(option) {
+			item := int(dl.entries[entry].item)
+			if dl.items[item].secondary {
+				continue
+			}
+
+			st.item = item
+			head := dl.items[item].head
+			for e := int(dl.entries[head].down); e != head; e = int(dl.entries[e].down) {
+				st.options = append(st.options, int(dl.entries[e].option))
+			}
+			if dl.order == OrderReverseLexicographic {
+				st.choices = append(st.choices, ^option, option)
+			} else {
+				st.choices = append(st.choices, option, ^option)
+			}
+			return true
+		}
+	}
+
+	// No option is left to cover the remaining items.
+	st.item = -1
+	return true
+}
+
+// The first item left to cover in the static order, or root if there
+// are none.
+func (dl *DLX) staticItem() int {
+	first := dl.itemHead.right
+	if first == root || dl.itemRank == nil {
+		return first
+	}
+
+	for index := dl.items[first].right; index != root; index = dl.items[index].right {
+		if dl.itemBefore(index, first) {
+			first = index
+		}
+	}
+	return first
+}
@@ -0,0 +1,28 @@
+package dancinglinks
+
+// NewWithPayloads is like New, but attaches an arbitrary payload to
+// each option, e.g. the domain object the option stands for, to be
+// retrieved with Payload.
+func NewWithPayloads(itemCount int, options [][]int, payloads []any) *DLX {
+	dl := New(itemCount, options)
+	dl.payloads = append([]any{}, payloads...)
+	return dl
+}
+
+// Payload returns the payload attached to an option, or nil if none.
+func (dl *DLX) Payload(option int) any {
+	if option < len(dl.payloads) {
+		return dl.payloads[option]
+	}
+	return nil
+}
+
+// Payloads returns the payloads attached to the options of a cover,
+// which must all be of type T.
+func Payloads[T any](dl *DLX, cover []int) []T {
+	payloads := make([]T, len(cover))
+	for i, option := range cover {
+		payloads[i] = dl.Payload(option).(T)
+	}
+	return payloads
+}
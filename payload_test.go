@@ -0,0 +1,20 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPayloads(t *testing.T) {
+	dl := NewWithPayloads(classic.itemCount, classic.options, []any{
+		"a", "b", "c", "d", "e", "f",
+	})
+	cover := dl.AnyCover()
+	if payloads := Payloads[string](dl, cover); !reflect.DeepEqual(payloads, []string{"d", "e", "a"}) {
+		t.Errorf("should be [d e a], got %v", payloads)
+	}
+
+	if payload := classic.toDLX().Payload(0); payload != nil {
+		t.Errorf("should have no payload, got %v", payload)
+	}
+}
@@ -0,0 +1,45 @@
+package dancinglinks
+
+// Cover removes an item from the items left to cover, and hides every
+// option covering it, as in Knuth's Algorithm X, so that
+// RemainingChoices no longer lists them.  These primitives are meant
+// for building custom search strategies: covers must be undone by
+// Uncover in reverse order, and before forcing options or searching
+// in any other way.  Colors are not taken into account.
+func (dl *DLX) Cover(item int) {
+	dl.cover(item)
+}
+
+// Uncover undoes Cover for the same item.
+func (dl *DLX) Uncover(item int) {
+	dl.uncover(item)
+}
+
+// CoverOption covers every item of an option, in order, which commits
+// to the option as part of the solution.
+func (dl *DLX) CoverOption(option int) {
+	for entry := range dl.row(option) {
+		dl.Cover(int(dl.entries[entry].item))
+	}
+}
+
+// UncoverOption undoes CoverOption for the same option.
+func (dl *DLX) UncoverOption(option int) {
+	first := int(dl.options[option])
+	if first == -1 {
+		return
+	}
+	for e := int(dl.entries[first].left); ; e = int(dl.entries[e].left) {
+		dl.Uncover(int(dl.entries[e].item))
+		if e == first {
+			break
+		}
+	}
+}
+
+// NextItem returns the item left to cover with the fewest remaining
+// choices, with ties broken by the tie-breaking policy, or -1 if no
+// items are left to cover.
+func (dl *DLX) NextItem() int {
+	return dl.nextItem()
+}
@@ -0,0 +1,83 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Knuth's Algorithm X, built from the exported primitives.
+func algorithmX(dl *DLX, path []int, yield func([]int)) {
+	item := dl.NextItem()
+	if item == -1 {
+		yield(append([]int{}, path...))
+		return
+	}
+
+	choices := dl.RemainingChoices(item)
+	dl.Cover(item)
+	for _, option := range choices {
+		items := dl.Option(option)
+		for _, other := range items {
+			if other != item {
+				dl.Cover(other)
+			}
+		}
+		algorithmX(dl, append(path, option), yield)
+		for i := len(items) - 1; i >= 0; i-- {
+			if items[i] != item {
+				dl.Uncover(items[i])
+			}
+		}
+	}
+	dl.Uncover(item)
+}
+
+func TestPrimitives(t *testing.T) {
+	for _, e := range []example{classic, classicDuplicates, impossible, trivial} {
+		dl := e.toDLX()
+		covers := [][]int{}
+		algorithmX(dl, nil, func(cover []int) {
+			covers = append(covers, cover)
+		})
+		sortSequences(covers)
+		if expected := coverSet(e.solution); !reflect.DeepEqual(covers, expected) {
+			t.Errorf("should be %v, got %v", expected, covers)
+		}
+
+		// The primitives leave the DLX intact.
+		testExample(t, dl.AllSolutions(), e.solution)
+	}
+}
+
+func TestNextItemStarved(t *testing.T) {
+	// Outside a search, an item without choices is still found after
+	// one with a single choice.
+	dl := New(2, [][]int{{0}})
+	if item := dl.NextItem(); item != 1 {
+		t.Errorf("should pick item 1, without choices, got %d", item)
+	}
+	if item, options := dl.NewSession().Candidates(); item != 1 || len(options) != 0 {
+		t.Errorf("should hit a dead end at item 1, got %d, %v", item, options)
+	}
+
+	// Likewise once items are added after searching.
+	dl = New(1, [][]int{{0}})
+	dl.AllCovers()
+	dl.AddItem()
+	if item := dl.NextItem(); item != 1 {
+		t.Errorf("should pick added item 1, without choices, got %d", item)
+	}
+}
+
+func TestCoverOption(t *testing.T) {
+	dl := classic.toDLX()
+	dl.CoverOption(0)
+	if item := dl.NextItem(); item != 1 {
+		t.Errorf("expected item 1 next, got %d", item)
+	}
+	if choices := dl.RemainingChoices(0); !reflect.DeepEqual(choices, []int{1, 3}) {
+		t.Errorf("expected choices [1 3] for item 0, got %v", choices)
+	}
+	dl.UncoverOption(0)
+	testExample(t, dl.AllSolutions(), classic.solution)
+}
@@ -0,0 +1,253 @@
+package dancinglinks
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// The protobuf wire types used by dancinglinks.proto.
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+var errProtoTruncated = errors.New("dancinglinks: truncated protobuf message")
+
+// MarshalProto encodes the problem definition as a Problem message of
+// dancinglinks.proto, for exchange with programs in other languages.
+// Like MarshalJSON, it leaves out settings, costs, and payloads.
+func (dl *DLX) MarshalProto() ([]byte, error) {
+	p := dl.data()
+	b := appendProtoVarint(nil, 1, uint64(p.Items))
+	b = appendProtoPacked(b, 2, p.Secondary)
+	for i, items := range p.Options {
+		option := appendProtoPacked(nil, 1, items)
+		if p.Colors != nil {
+			colors := make([]uint64, len(p.Colors[i]))
+			for j, color := range p.Colors[i] {
+				colors[j] = uint64(uint32(color<<1 ^ color>>31))
+			}
+			option = appendProtoPackedUint64(option, 2, colors)
+		}
+		b = appendProtoBytes(b, 3, option)
+	}
+	for _, label := range p.ItemLabels {
+		b = appendProtoBytes(b, 4, []byte(label))
+	}
+	for _, label := range p.OptionLabels {
+		b = appendProtoBytes(b, 5, []byte(label))
+	}
+	b = appendProtoPacked(b, 6, p.Forced)
+	b = appendProtoPacked(b, 7, p.Disabled)
+	return b, nil
+}
+
+// UnmarshalProto replaces the problem with one encoded as a Problem
+// message, with default settings.  Unknown fields are skipped.  To
+// bound the memory a short message can claim, it rejects problems with
+// more items than the message has bits, which no problem whose items
+// are each covered by an option or labeled comes near.
+func (dl *DLX) UnmarshalProto(data []byte) error {
+	var p problemData
+	colored := false
+	err := readProto(data, func(field int, value uint64, bytes []byte) error {
+		var err error
+		switch field {
+		case 1:
+			if value > math.MaxInt32 || value > 8*uint64(len(data)) {
+				return fmt.Errorf("dancinglinks: item count %d out of range", value)
+			}
+			p.Items = int(value)
+		case 2:
+			p.Secondary, err = appendProtoInts(p.Secondary, value, bytes)
+		case 3:
+			if bytes == nil {
+				return errors.New("dancinglinks: option is not a message")
+			}
+			items, colors := []int{}, []int{}
+			err = readProto(bytes, func(field int, value uint64, bytes []byte) error {
+				var err error
+				switch field {
+				case 1:
+					items, err = appendProtoInts(items, value, bytes)
+				case 2:
+					var zigzag []int
+					if zigzag, err = appendProtoInts(nil, value, bytes); err == nil {
+						for _, z := range zigzag {
+							colors = append(colors, int(int32(uint32(z)>>1)^-int32(z&1)))
+						}
+					}
+				}
+				return err
+			})
+			colored = colored || len(colors) > 0
+			p.Options = append(p.Options, items)
+			p.Colors = append(p.Colors, colors)
+		case 4:
+			p.ItemLabels = append(p.ItemLabels, string(bytes))
+		case 5:
+			p.OptionLabels = append(p.OptionLabels, string(bytes))
+		case 6:
+			p.Forced, err = appendProtoInts(p.Forced, value, bytes)
+		case 7:
+			p.Disabled, err = appendProtoInts(p.Disabled, value, bytes)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Options assigning no colors leave them out.
+	if !colored {
+		p.Colors = nil
+	}
+	for i, colors := range p.Colors {
+		if len(colors) == 0 {
+			p.Colors[i] = make([]int, len(p.Options[i]))
+		}
+	}
+
+	decoded, err := p.build()
+	if err != nil {
+		return err
+	}
+	*dl = *decoded
+	return nil
+}
+
+// MarshalProto encodes the solution as a Solution message of
+// dancinglinks.proto, with the names of the selected options if the
+// problem is labeled.
+func (s Solution) MarshalProto() ([]byte, error) {
+	b := appendProtoPacked(nil, 1, s.Options)
+	if s.dl != nil && s.dl.optionLabels != nil {
+		for _, label := range s.Labels() {
+			b = appendProtoBytes(b, 2, []byte(label))
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalProto decodes the selected options of a Solution message.
+// As with UnmarshalJSON, the decoded solution does not know its
+// problem.
+func (s *Solution) UnmarshalProto(data []byte) error {
+	options := []int{}
+	err := readProto(data, func(field int, value uint64, bytes []byte) error {
+		var err error
+		if field == 1 {
+			options, err = appendProtoInts(options, value, bytes)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	*s = Solution{Options: options}
+	return nil
+}
+
+func appendProtoVarint(b []byte, field int, value uint64) []byte {
+	b = binary.AppendUvarint(b, uint64(field)<<3|wireVarint)
+	return binary.AppendUvarint(b, value)
+}
+
+func appendProtoBytes(b []byte, field int, value []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(field)<<3|wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(value)))
+	return append(b, value...)
+}
+
+// Appends a packed repeated field, or nothing if it is empty.
+func appendProtoPacked(b []byte, field int, values []int) []byte {
+	packed := make([]uint64, len(values))
+	for i, v := range values {
+		packed[i] = uint64(v)
+	}
+	return appendProtoPackedUint64(b, field, packed)
+}
+
+func appendProtoPackedUint64(b []byte, field int, values []uint64) []byte {
+	if len(values) == 0 {
+		return b
+	}
+	var packed []byte
+	for _, v := range values {
+		packed = binary.AppendUvarint(packed, v)
+	}
+	return appendProtoBytes(b, field, packed)
+}
+
+// Calls visit with each field of a message: its number, and either its
+// value if it is a varint, or its contents.  Fixed-size fields are
+// skipped.
+func readProto(data []byte, visit func(field int, value uint64, bytes []byte) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errProtoTruncated
+		}
+		data = data[n:]
+
+		field := int(key >> 3)
+		var value uint64
+		var bytes []byte
+		switch key & 7 {
+		case wireVarint:
+			if value, n = binary.Uvarint(data); n <= 0 {
+				return errProtoTruncated
+			}
+			data = data[n:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return errProtoTruncated
+			}
+			bytes = data[n : n+int(length)]
+			data = data[n+int(length):]
+		case 1, 5:
+			size := 8
+			if key&7 == 5 {
+				size = 4
+			}
+			if len(data) < size {
+				return errProtoTruncated
+			}
+			data = data[size:]
+			continue
+		default:
+			return fmt.Errorf("dancinglinks: unsupported protobuf wire type %d", key&7)
+		}
+
+		if err := visit(field, value, bytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Appends the values of a repeated integer field, given either one
+// value or a packed list of them.
+func appendProtoInts(list []int, value uint64, bytes []byte) ([]int, error) {
+	if bytes == nil {
+		if value > math.MaxUint32 {
+			return nil, fmt.Errorf("dancinglinks: index %d out of range", value)
+		}
+		return append(list, int(value)), nil
+	}
+	for len(bytes) > 0 {
+		v, n := binary.Uvarint(bytes)
+		if n <= 0 {
+			return nil, errProtoTruncated
+		}
+		if v > math.MaxUint32 {
+			return nil, fmt.Errorf("dancinglinks: index %d out of range", v)
+		}
+		list = append(list, int(v))
+		bytes = bytes[n:]
+	}
+	return list, nil
+}
@@ -0,0 +1,97 @@
+package dancinglinks
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestProto(t *testing.T) {
+	labeled, err := NewLabeled(sushiItems, sushiOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	labeled.DisableOption(5)
+	labeled.ForceOptions(0)
+	labeled.DisableOption(0)
+
+	for _, dl := range []*DLX{
+		classicDuplicates.toDLX(),
+		queens(4),
+		NewColored(3, 2, [][]ColoredItem{
+			{{0, 0}, {1, 0}, {3, 0}, {4, 1}},
+			{{0, 0}, {2, 0}, {3, 1}, {4, 0}},
+			{{0, 0}, {3, -2}},
+			{{1, 0}},
+			{{2, 0}, {4, 2}},
+		}),
+		New(3, [][]int{{0}, {1, 2}}, WithSecondaryItems(1)),
+		labeled,
+	} {
+		data, err := dl.MarshalProto()
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := &DLX{}
+		if err := decoded.UnmarshalProto(data); err != nil {
+			t.Fatalf("should decode %v, got %v", dl, err)
+		}
+		if !decoded.Equal(dl) {
+			t.Errorf("should decode %v, got %v", dl, decoded)
+		}
+		if got, want := decoded.String(), dl.String(); got != want {
+			t.Errorf("should decode to\n%s\ngot\n%s", want, got)
+		}
+	}
+
+	// The encoding follows dancinglinks.proto, and decoding accepts
+	// unpacked fields as well as unknown ones.
+	data, err := New(2, [][]int{{0, 1}}).MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x08, 2, 0x1a, 4, 0x0a, 2, 0, 1}; !bytes.Equal(data, want) {
+		t.Errorf("should encode to % x, got % x", want, data)
+	}
+	decoded := &DLX{}
+	if err := decoded.UnmarshalProto([]byte{0x08, 2, 0x1a, 4, 0x08, 0, 0x08, 1, 0x4d, 1, 2, 3, 4}); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(New(2, [][]int{{0, 1}})) {
+		t.Errorf("should decode unpacked items, got %v", decoded)
+	}
+
+	for _, data := range [][]byte{
+		{0x08},
+		{0x1a, 4, 0x0a},
+		{0x08, 1, 0x1a, 2, 0x08, 1},
+		{0x08, 1, 0x1a, 4, 0x0a, 2, 0, 0},
+		{0x0b},
+		{0x08, 0xff, 0xff, 0xff, 0xff, 0x07},
+	} {
+		if err := decoded.UnmarshalProto(data); err == nil {
+			t.Errorf("should reject % x", data)
+		}
+	}
+}
+
+func TestSolutionProto(t *testing.T) {
+	dl, err := NewLabeled(sushiItems, sushiOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := dl.Solution(dl.AnyCover()).MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s Solution
+	if err := s.UnmarshalProto(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s.Options, []int{3, 4, 0}) {
+		t.Errorf("should decode to [3 4 0], got %v", s.Options)
+	}
+	if !bytes.Contains(data, []byte("catch of the day")) {
+		t.Errorf("should encode the labels, got %q", data)
+	}
+}
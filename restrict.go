@@ -0,0 +1,23 @@
+package dancinglinks
+
+// Restrict returns a copy of the DLX that only requires covering the
+// given items: the other primary items become secondary, so that
+// options may still cover them at most once, but they need not be
+// covered.  Forced options and settings carry over as with Clone, and
+// the copy shares nothing with the original, so that several regions
+// of a large problem can be solved in turn without setting it up
+// anew.
+func (dl *DLX) Restrict(items []int) *DLX {
+	required := make([]bool, len(dl.items))
+	for _, item := range items {
+		required[item] = true
+	}
+
+	view := dl.Clone()
+	for index := range view.items {
+		if !required[index] {
+			view.makeSecondary(index)
+		}
+	}
+	return view
+}
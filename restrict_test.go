@@ -0,0 +1,61 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRestrict(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	required := []int{0, 1, 2}
+	view := dl.Restrict(required)
+
+	// Brute force: the sets of options covering the required items
+	// exactly once and the others at most once.
+	expected := [][]int{}
+	for subset := 0; subset < 1<<len(classicDuplicates.options); subset++ {
+		counts := make([]int, classicDuplicates.itemCount)
+		cover := []int{}
+		for option, items := range classicDuplicates.options {
+			if subset&(1<<option) != 0 {
+				cover = append(cover, option)
+				for _, item := range items {
+					counts[item]++
+				}
+			}
+		}
+
+		ok := true
+		for item, count := range counts {
+			if count > 1 || count == 0 && intSliceContains(required, item) {
+				ok = false
+			}
+		}
+		if ok {
+			expected = append(expected, cover)
+		}
+	}
+
+	covers := view.AllCovers()
+	sortSequences(covers)
+	sortSequences(expected)
+	if !reflect.DeepEqual(covers, expected) {
+		t.Errorf("should be %v, got %v", expected, covers)
+	}
+
+	// The original is left alone.
+	if count := dl.CountSolutions().Int64(); count != 4 {
+		t.Errorf("original should still have 4 solutions, has %d", count)
+	}
+
+	// Forced options carry over, and items they cover stay covered.
+	dl.ForceOptions(2)
+	view = dl.Restrict([]int{1, 2})
+	if covers := view.AllCovers(); !reflect.DeepEqual(covers, [][]int{{3}}) {
+		t.Errorf("should find [[3]] with option 2 forced, found %v", covers)
+	}
+	view.UnforceOptions()
+	if count := view.CountSolutions().Int64(); count == 0 {
+		t.Errorf("should still have covers after unforcing")
+	}
+}
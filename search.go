@@ -0,0 +1,325 @@
+package dancinglinks
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	// ErrCheckpoint is returned when resuming a search from a
+	// checkpoint that does not fit the problem.
+	ErrCheckpoint = errors.New("dancinglinks: checkpoint does not match the problem")
+
+	// ErrRandomized is returned when resuming a search with randomized
+	// branching or tie-breaking, whose path cannot be retraced.
+	ErrRandomized = errors.New("dancinglinks: cannot resume a randomized search")
+)
+
+// A Search is an enumeration of solutions in progress, producing one
+// solution at a time.  It can be paused at any point, recording its
+// progress in a Checkpoint from which it can later be resumed, even
+// in another process.  While a search is in progress, the DLX must
+// not be used for anything else.
+type Search struct {
+	dl *DLX
+
+	// Stack of search tree nodes, from the root down to the current
+	// node.  Empty if the search has not started yet.
+	stages []*stage
+
+	// Whether the search has started.
+	started bool
+
+	// The steps taken to reach the current node.
+	path []Step
+
+	// Whether the search is over.
+	done bool
+}
+
+// A Checkpoint records the progress of a search.  It only refers to
+// positions in the search tree, so it is small and can be serialized
+// as is, or with MarshalBinary.
+type Checkpoint struct {
+	// Whether the search is over.
+	Done bool
+
+	// For each node on the current search path, from the root down,
+	// the number of moves tried so far.  Empty if the search has not
+	// started yet.
+	Progress []int
+}
+
+// NewSearch starts a new search, which produces the same solutions in
+// the same order as GenerateSolutions.
+func (dl *DLX) NewSearch() *Search {
+	// Each step covers at least one item, so unless some steps exclude
+	// options instead, the stacks never outgrow the items left.
+	return &Search{
+		dl:     dl,
+		stages: make([]*stage, 0, dl.uncovered+1),
+		path:   make([]Step, 0, dl.uncovered),
+		done:   !dl.checkCoverable(),
+	}
+}
+
+// Next returns the next solution, or false if there are no solutions
+// left.  If the search was cut short by a limit, Err says so.
+func (s *Search) Next() ([]Step, bool) {
+	return s.next(true)
+}
+
+// Finds the next solution, returning either a copy of the search path
+// or the path itself, which the search overwrites as it goes on.
+func (s *Search) next(copied bool) ([]Step, bool) {
+	dl := s.dl
+	if s.done {
+		return nil, false
+	}
+
+	if !s.started {
+		s.started = true
+		if !s.push(-1, -1) {
+			s.pop()
+			return []Step{}, true
+		}
+	}
+
+	for {
+		st := s.stages[len(s.stages)-1]
+
+		if st.i == len(st.choices) {
+			if !s.pop() {
+				return nil, false
+			}
+			continue
+		}
+
+		// Past the depth limit, there is no room left to cover the
+		// remaining items.
+		if dl.depthLimit > 0 && len(s.path) >= dl.depthLimit {
+			dl.depthCutoff = true
+			st.i = len(st.choices)
+			continue
+		}
+
+		if !dl.visit() {
+			s.Close()
+			return nil, false
+		}
+
+		move := st.choices[st.i]
+		st.i++
+
+		dl.move(move)
+		if dl.deadEnd() {
+			dl.unmove(move)
+			continue
+		}
+
+		if move >= 0 {
+			// Skip options that would only lead to symmetric copies of
+			// other solutions.
+			if !dl.symmetryAllows(move, s.path) {
+				dl.unmove(move)
+				continue
+			}
+
+			s.path = append(s.path, dl.step(st, move, len(s.path)))
+		}
+
+		// Consider each option that covers the next item.
+		if !s.push(move, optionOfMove(move)) && dl.symmetryHolds(s.path) {
+			if !copied {
+				return s.path, true
+			}
+			return copyPath(s.path), true
+		}
+	}
+}
+
+// Pushes the search tree node reached by the given move, which
+// involves the given option (or -1 at the root), reporting whether
+// anything is left to cover from there.
+func (s *Search) push(move int, after int) bool {
+	dl := s.dl
+	st := dl.newStage()
+	left := dl.branch(after, st)
+	st.parent = move
+	st.remaining = dl.uncoveredCount()
+	st.i = 0
+	s.stages = append(s.stages, st)
+	return left
+}
+
+// Takes a stage left over from an earlier search, or allocates a new
+// one.  Reusing stages, along with their buffers, spares repeated
+// searches most of their allocations.
+func (dl *DLX) newStage() *stage {
+	if n := len(dl.spareStages); n > 0 {
+		st := dl.spareStages[n-1]
+		dl.spareStages = dl.spareStages[:n-1]
+		return st
+	}
+	return &stage{}
+}
+
+// Copies a search path, including the steps' choices, which refer to
+// buffers the search reuses.
+func copyPath(path []Step) []Step {
+	total := 0
+	for _, step := range path {
+		total += len(step.Choices)
+	}
+
+	steps := make([]Step, len(path))
+	choices := make([]int, 0, total)
+	for i, step := range path {
+		if step.Choices != nil {
+			start := len(choices)
+			choices = append(choices, step.Choices...)
+			step.Choices = choices[start:len(choices):len(choices)]
+		}
+		steps[i] = step
+	}
+	return steps
+}
+
+// The step selecting the given option at a search tree node, at the
+// given depth.
+func (dl *DLX) step(st *stage, option int, depth int) Step {
+	step := Step{
+		Item:      st.item,
+		Option:    option,
+		Depth:     depth,
+		Remaining: st.remaining,
+	}
+	if !dl.omitChoices {
+		step.Choices = st.options
+	}
+	if dl.itemLabels != nil {
+		step.ItemLabel = dl.itemLabels[st.item]
+	}
+	if dl.optionLabels != nil {
+		step.OptionLabel = dl.optionLabels[option]
+	}
+	return step
+}
+
+// Backtracks from the current search tree node, reporting whether
+// there is anywhere left to backtrack to.
+func (s *Search) pop() bool {
+	st := s.stages[len(s.stages)-1]
+	s.stages = s.stages[:len(s.stages)-1]
+	s.dl.spareStages = append(s.dl.spareStages, st)
+
+	if len(s.stages) == 0 {
+		s.done = true
+		return false
+	}
+
+	if st.parent >= 0 {
+		s.path = s.path[:len(s.path)-1]
+	}
+	s.dl.unmove(st.parent)
+	return true
+}
+
+// Close abandons the search, restoring the DLX to its state from
+// before the search.
+func (s *Search) Close() {
+	for len(s.stages) > 0 && s.pop() {
+	}
+	s.done = true
+}
+
+// Err returns the reason the search was cut short, if any.
+func (s *Search) Err() error {
+	return s.dl.searchErr()
+}
+
+// Checkpoint records the progress of the search.
+func (s *Search) Checkpoint() Checkpoint {
+	progress := make([]int, len(s.stages))
+	for i, st := range s.stages {
+		progress[i] = st.i
+	}
+	return Checkpoint{Done: s.done, Progress: progress}
+}
+
+// ResumeSearch resumes a search from a checkpoint recorded on a DLX
+// set up the same way, including any forced options and search
+// settings.  Searches with randomized branching or tie-breaking cannot
+// be resumed.
+func (dl *DLX) ResumeSearch(checkpoint Checkpoint) (*Search, error) {
+	s := dl.NewSearch()
+	if s.done || checkpoint.Done || len(checkpoint.Progress) == 0 {
+		s.done = s.done || checkpoint.Done
+		return s, nil
+	}
+
+	if dl.rng != nil || dl.tieBreak == TieRandom {
+		return nil, ErrRandomized
+	}
+
+	s.started = true
+	if !s.push(-1, -1) {
+		s.pop()
+		return nil, ErrCheckpoint
+	}
+
+	// Retrace the path down to the node where the search was paused.
+	for depth, progress := range checkpoint.Progress {
+		st := s.stages[depth]
+		last := depth == len(checkpoint.Progress)-1
+		if progress < 0 || progress > len(st.choices) || (!last && progress == 0) {
+			s.Close()
+			return nil, ErrCheckpoint
+		}
+
+		st.i = progress
+		if last {
+			break
+		}
+
+		move := st.choices[progress-1]
+		dl.move(move)
+		if move >= 0 {
+			s.path = append(s.path, dl.step(st, move, len(s.path)))
+		}
+		s.push(move, optionOfMove(move))
+	}
+
+	return s, nil
+}
+
+// MarshalBinary encodes the checkpoint compactly.
+func (c Checkpoint) MarshalBinary() ([]byte, error) {
+	data := []byte{0}
+	if c.Done {
+		data[0] = 1
+	}
+	for _, progress := range c.Progress {
+		data = binary.AppendUvarint(data, uint64(progress))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a checkpoint encoded with MarshalBinary.
+func (c *Checkpoint) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] > 1 {
+		return ErrCheckpoint
+	}
+
+	c.Done = data[0] == 1
+	c.Progress = []int{}
+	for data = data[1:]; len(data) > 0; {
+		progress, n := binary.Uvarint(data)
+		if n <= 0 {
+			return ErrCheckpoint
+		}
+		c.Progress = append(c.Progress, int(progress))
+		data = data[n:]
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package dancinglinks
+
+import (
+	"testing"
+)
+
+func TestSearchResume(t *testing.T) {
+	for pause := 0; pause <= len(classicDuplicates.solution); pause++ {
+		search := classicDuplicates.toDLX().NewSearch()
+		solutions := [][]Step{}
+		for i := 0; i < pause; i++ {
+			solution, ok := search.Next()
+			if !ok {
+				t.Fatalf("search ended after %d solutions", i)
+			}
+			solutions = append(solutions, solution)
+		}
+
+		data, err := search.Checkpoint().MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		search.Close()
+
+		var checkpoint Checkpoint
+		if err := checkpoint.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+
+		// Resume on a fresh copy of the problem.
+		search, err = classicDuplicates.toDLX().ResumeSearch(checkpoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for {
+			solution, ok := search.Next()
+			if !ok {
+				break
+			}
+			solutions = append(solutions, solution)
+		}
+
+		testExample(t, solutions, classicDuplicates.solution)
+	}
+}
+
+func TestSearchResumeInvalid(t *testing.T) {
+	_, err := classic.toDLX().ResumeSearch(Checkpoint{Progress: []int{5, 0}})
+	if err != ErrCheckpoint {
+		t.Errorf("should reject checkpoint, got %v", err)
+	}
+
+	dl := classic.toDLX()
+	dl.Randomize(1)
+	if _, err := dl.ResumeSearch(Checkpoint{Progress: []int{1, 0}}); err != ErrRandomized {
+		t.Errorf("should reject randomized search, got %v", err)
+	}
+
+	dl = classic.toDLX()
+	dl.SetTieBreak(TieRandom)
+	if _, err := dl.ResumeSearch(Checkpoint{Progress: []int{1, 0}}); err != ErrRandomized {
+		t.Errorf("should reject search breaking ties at random, got %v", err)
+	}
+}
+
+func TestSearchReusesStages(t *testing.T) {
+	dl := classicDuplicates.toDLX()
+	solutions := dl.AllSolutions()
+
+	// Later searches reuse the stages of earlier ones, without
+	// clobbering the solutions returned by them.
+	dl.AllSolutions()
+	testExample(t, solutions, classicDuplicates.solution)
+
+	// Solving again allocates only a few slices for the whole search,
+	// rather than any for each of its nodes.
+	if allocs := testing.AllocsPerRun(10, func() {
+		dl.GenerateSolutionsNoCopy(func([]Step) bool { return true })
+	}); allocs > 10 {
+		t.Errorf("repeated searches should reuse their stages, allocated %v times", allocs)
+	}
+
+	// The stacks are sized for the deepest path up front, so that even
+	// deep searches do not grow them.
+	itemCount, options := latinSquare(5)
+	dl = New(itemCount, options)
+	dl.AllSolutionsN(1)
+	if allocs := testing.AllocsPerRun(10, func() {
+		count := 0
+		dl.GenerateSolutionsNoCopy(func([]Step) bool {
+			count++
+			return count < 100
+		})
+	}); allocs > 3 {
+		t.Errorf("deep searches should not grow their stacks, allocated %v times", allocs)
+	}
+}
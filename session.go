@@ -0,0 +1,74 @@
+package dancinglinks
+
+import (
+	"fmt"
+)
+
+// A Session lets the caller drive the search by hand, one decision at
+// a time, e.g. in an interactive puzzle.  While a session is open, the
+// DLX must not be used otherwise.
+type Session struct {
+	dl *DLX
+
+	// The options chosen so far.
+	chosen []int
+}
+
+// NewSession opens a session on top of any forced options.
+func (dl *DLX) NewSession() *Session {
+	return &Session{dl: dl}
+}
+
+// Candidates returns the item the solver would cover next, i.e. one
+// with the fewest remaining choices, along with the options available
+// to cover it.  If there are none, the session has hit a dead end and
+// needs to backtrack.  If nothing is left to cover, the item is -1.
+func (s *Session) Candidates() (item int, options []int) {
+	return s.dl.nextChoices([]int{})
+}
+
+// Choose selects an option, which must still be available.
+func (s *Session) Choose(option int) error {
+	dl := s.dl
+	switch {
+	case option < 0 || option >= len(dl.options):
+		return fmt.Errorf("dancinglinks: option %d out of range [0, %d)", option, len(dl.options))
+	case !dl.optionLive(option):
+		return fmt.Errorf("dancinglinks: option %d is not available", option)
+	}
+
+	dl.chooseOption(option)
+	s.chosen = append(s.chosen, option)
+	return nil
+}
+
+// Backtrack takes back the most recent choice, reporting whether there
+// was one.
+func (s *Session) Backtrack() bool {
+	last := len(s.chosen) - 1
+	if last < 0 {
+		return false
+	}
+
+	s.dl.unchooseOption(s.chosen[last])
+	s.chosen = s.chosen[:last]
+	return true
+}
+
+// Solved reports whether the choices made, together with the forced
+// options, cover every primary item.
+func (s *Session) Solved() bool {
+	return s.dl.itemHead.right == root
+}
+
+// Chosen returns the options chosen so far, in order.
+func (s *Session) Chosen() []int {
+	return append([]int{}, s.chosen...)
+}
+
+// Close takes back all choices, restoring the DLX to its state from
+// before the session.
+func (s *Session) Close() {
+	for s.Backtrack() {
+	}
+}
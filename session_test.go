@@ -0,0 +1,58 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSession(t *testing.T) {
+	dl := classic.toDLX()
+	s := dl.NewSession()
+
+	item, options := s.Candidates()
+	if item != 0 || !reflect.DeepEqual(options, []int{1, 3}) {
+		t.Errorf("expected candidates 0, [1 3], got %d, %v", item, options)
+	}
+
+	// Choosing option 1 leads to a dead end.
+	if err := s.Choose(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Choose(3); err == nil {
+		t.Errorf("expected an error choosing a conflicting option")
+	}
+	if err := s.Choose(2); err != nil {
+		t.Fatal(err)
+	}
+	if item, options := s.Candidates(); item == -1 || len(options) != 0 {
+		t.Errorf("expected a dead end, got %d, %v", item, options)
+	}
+
+	if !s.Backtrack() || !s.Backtrack() {
+		t.Fatal("expected to backtrack twice")
+	}
+	if s.Backtrack() {
+		t.Errorf("expected nothing left to backtrack")
+	}
+
+	for _, option := range []int{3, 4, 0} {
+		if s.Solved() {
+			t.Errorf("solved before choosing %d", option)
+		}
+		if err := s.Choose(option); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !s.Solved() {
+		t.Errorf("expected to be solved")
+	}
+	if item, _ := s.Candidates(); item != -1 {
+		t.Errorf("expected no candidates once solved, got item %d", item)
+	}
+	if chosen := s.Chosen(); !reflect.DeepEqual(chosen, []int{3, 4, 0}) {
+		t.Errorf("expected chosen [3 4 0], got %v", chosen)
+	}
+
+	s.Close()
+	testExample(t, dl.AllSolutions(), classic.solution)
+}
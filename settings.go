@@ -0,0 +1,76 @@
+package dancinglinks
+
+// A Setting configures a DLX as it is set up by New.
+type Setting func(dl *DLX)
+
+// WithSeed randomizes the search as Randomize does.
+func WithSeed(seed int64) Setting {
+	return func(dl *DLX) { dl.Randomize(seed) }
+}
+
+// WithOrder sets the order of enumeration as SetOrder does.
+func WithOrder(order Order) Setting {
+	return func(dl *DLX) { dl.SetOrder(order) }
+}
+
+// WithTieBreak sets the tie-breaking policy as SetTieBreak does.
+func WithTieBreak(policy TieBreak) Setting {
+	return func(dl *DLX) { dl.SetTieBreak(policy) }
+}
+
+// WithItemOrder sets the order of preference for branching on tied
+// items as SetItemOrder does.
+func WithItemOrder(items []int) Setting {
+	return func(dl *DLX) { dl.SetItemOrder(items) }
+}
+
+// WithEngine sets the search engine as SetEngine does.
+func WithEngine(engine Engine) Setting {
+	return func(dl *DLX) { dl.SetEngine(engine) }
+}
+
+// WithoutChoices leaves Choices out of yielded steps as SetOmitChoices
+// does.
+func WithoutChoices() Setting {
+	return func(dl *DLX) { dl.SetOmitChoices(true) }
+}
+
+// WithNodeLimit limits searches as SetNodeLimit does.
+func WithNodeLimit(n int) Setting {
+	return func(dl *DLX) { dl.SetNodeLimit(n) }
+}
+
+// WithDepthLimit limits searches as SetDepthLimit does.
+func WithDepthLimit(n int) Setting {
+	return func(dl *DLX) { dl.SetDepthLimit(n) }
+}
+
+// WithSecondaryItems makes the last count items secondary, i.e. they
+// may be covered at most once but need not be covered, as with
+// NewColored.
+func WithSecondaryItems(count int) Setting {
+	return func(dl *DLX) {
+		for index := len(dl.items) - count; index < len(dl.items); index++ {
+			dl.makeSecondary(index)
+		}
+	}
+}
+
+// Turns a primary item into a secondary one, taking it out of the list
+// of items to cover.
+func (dl *DLX) makeSecondary(index int) {
+	item := &dl.items[index]
+	if item.secondary {
+		return
+	}
+	if dl.remaining(index) {
+		dl.item(item.left).right = item.right
+		dl.item(item.right).left = item.left
+		dl.uncovered--
+	}
+	item.left = index
+	item.right = index
+	item.secondary = true
+	dl.buckets = nil
+	dl.starvedExact = false
+}
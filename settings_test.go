@@ -0,0 +1,44 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSettings(t *testing.T) {
+	dl := New(classicDuplicates.itemCount, classicDuplicates.options, WithOrder(OrderLexicographic))
+	covers := dl.AllCovers()
+	if expected := [][]int{{0, 4, 6}, {0, 5, 6}, {1, 4, 6}, {1, 5, 6}}; !reflect.DeepEqual(covers, expected) {
+		t.Errorf("should be %v, got %v", expected, covers)
+	}
+
+	dl = New(classic.itemCount, classic.options, WithNodeLimit(1))
+	dl.AllSolutions()
+	if err := dl.Err(); err != ErrNodeLimit {
+		t.Errorf("should hit the node limit, got %v", err)
+	}
+
+	dl = New(classicDuplicates.itemCount, classicDuplicates.options, WithSeed(1))
+	if covers, expected := coverSet(dl.AllSolutions()), coverSet(classicDuplicates.solution); !reflect.DeepEqual(covers, expected) {
+		t.Errorf("should be %v, got %v", expected, covers)
+	}
+}
+
+func TestWithSecondaryItems(t *testing.T) {
+	// With items 5 and 6 secondary, the classic solution is the only
+	// cover still.
+	dl := New(classic.itemCount, classic.options, WithSecondaryItems(2))
+	covers := dl.AllCovers()
+	sortSequences(covers)
+	if expected := [][]int{{0, 3, 4}}; !reflect.DeepEqual(covers, expected) {
+		t.Errorf("should be %v, got %v", expected, covers)
+	}
+
+	// With item 4 secondary, it may be left uncovered.
+	dl = New(5, [][]int{{0, 1}, {2, 3, 4}, {0, 2}, {1, 3}}, WithSecondaryItems(1))
+	covers = dl.AllCovers()
+	sortSequences(covers)
+	if expected := [][]int{{0, 1}, {2, 3}}; !reflect.DeepEqual(covers, expected) {
+		t.Errorf("should be %v, got %v", expected, covers)
+	}
+}
@@ -0,0 +1,59 @@
+package dancinglinks
+
+import (
+	"unsafe"
+)
+
+// Size reports how big a DLX is.
+type Size struct {
+	// The number of items, primary and secondary, and of options.
+	Items   int
+	Options int
+
+	// The number of entries, i.e. of items covered by each option,
+	// summed over all options.
+	Entries int
+
+	// An estimate of the memory taken up by the structure, in bytes,
+	// including labels and other data attached to it, but not the
+	// payloads' contents.
+	Bytes int
+}
+
+// Size reports the size of the DLX, for capacity planning.
+func (dl *DLX) Size() Size {
+	entries := len(dl.entries) - len(dl.items)
+
+	const word = int(unsafe.Sizeof(0))
+	bytes := int(unsafe.Sizeof(*dl)) +
+		cap(dl.entries)*int(unsafe.Sizeof(entryNode{})) +
+		cap(dl.items)*int(unsafe.Sizeof(itemNode{})) +
+		cap(dl.options)*4 +
+		(cap(dl.hidden)+cap(dl.selected)+cap(dl.disabled)+cap(dl.costs)+cap(dl.symmetryPrev)+cap(dl.itemRank))*word +
+		cap(dl.payloads)*int(unsafe.Sizeof(any(nil))) +
+		labelBytes(dl.itemLabels) + labelBytes(dl.optionLabels) +
+		cap(dl.itemIDs)*8 + len(dl.itemIndices)*2*word
+
+	if b := dl.buckets; b != nil {
+		bytes += int(unsafe.Sizeof(*b)) + (cap(b.next)+cap(b.prev))*word
+	}
+	if c := dl.conflicts; c != nil {
+		bytes += int(unsafe.Sizeof(*c)) + (cap(c.start)+cap(c.options)+cap(c.spans)+cap(c.entries))*4
+	}
+
+	return Size{
+		Items:   len(dl.items),
+		Options: len(dl.options),
+		Entries: entries,
+		Bytes:   bytes,
+	}
+}
+
+// The memory taken up by a list of labels.
+func labelBytes(labels []string) int {
+	bytes := cap(labels) * int(unsafe.Sizeof(""))
+	for _, label := range labels {
+		bytes += len(label)
+	}
+	return bytes
+}
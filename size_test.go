@@ -0,0 +1,32 @@
+package dancinglinks
+
+import (
+	"testing"
+)
+
+func TestSize(t *testing.T) {
+	size := classic.toDLX().Size()
+	if size.Items != 7 || size.Options != 6 || size.Entries != 16 {
+		t.Errorf("should have 7 items, 6 options and 16 entries, got %+v", size)
+	}
+
+	itemCount, options := latinSquare(25)
+	dl := New(itemCount, options)
+	size = dl.Size()
+	if size.Entries != 3*25*25*25 {
+		t.Errorf("should have %d entries, got %d", 3*25*25*25, size.Entries)
+	}
+
+	// Each entry takes a node, linked into its option's row, and each
+	// option its first entry and its count of what hides it.
+	least := size.Entries*28 + size.Options*(4+8)
+	if size.Bytes < least || size.Bytes > 2*least {
+		t.Errorf("should take a bit over %d bytes, estimated %d", least, size.Bytes)
+	}
+
+	// Forcing options changes what is left, but not the size.
+	dl.ForceOptions(0)
+	if forced := dl.Size(); forced.Entries != size.Entries {
+		t.Errorf("forcing should not change the entries, got %+v", forced)
+	}
+}
@@ -0,0 +1,64 @@
+package dancinglinks
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A Solution is a cover together with the problem it covers, so that
+// its options can be translated back to names and payloads.
+type Solution struct {
+	// The selected options.
+	Options []int
+
+	dl *DLX
+}
+
+// Solution wraps a cover, such as one yielded by GenerateCovers, for
+// decoding.
+func (dl *DLX) Solution(cover []int) Solution {
+	return Solution{cover, dl}
+}
+
+// Labels returns the names of the selected options.
+func (s Solution) Labels() []string {
+	return s.dl.LabelCover(s.Options)
+}
+
+// Payloads returns the payloads attached to the selected options.
+func (s Solution) Payloads() []any {
+	payloads := make([]any, len(s.Options))
+	for i, option := range s.Options {
+		payloads[i] = s.dl.Payload(option)
+	}
+	return payloads
+}
+
+// Decode stores the payloads of the selected options in the slice dst
+// points to, whose element type each payload must be assignable to.
+// Options without a payload decode to the zero value.
+func (s Solution) Decode(dst any) error {
+	ptr := reflect.ValueOf(dst)
+	if ptr.Kind() != reflect.Pointer || ptr.IsNil() || ptr.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("dancinglinks: Decode needs a non-nil pointer to a slice, got %T", dst)
+	}
+
+	slice := ptr.Elem()
+	elem := slice.Type().Elem()
+	decoded := reflect.MakeSlice(slice.Type(), len(s.Options), len(s.Options))
+	for i, option := range s.Options {
+		payload := s.dl.Payload(option)
+		if payload == nil {
+			continue
+		}
+
+		value := reflect.ValueOf(payload)
+		if !value.Type().AssignableTo(elem) {
+			return fmt.Errorf("dancinglinks: payload of option %d has type %T, not assignable to %s", option, payload, elem)
+		}
+		decoded.Index(i).Set(value)
+	}
+
+	slice.Set(decoded)
+	return nil
+}
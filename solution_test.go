@@ -0,0 +1,46 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSolutionDecode(t *testing.T) {
+	dl := NewWithPayloads(classic.itemCount, classic.options, []any{
+		"a", "b", "c", "d", "e", "f",
+	})
+	s := dl.Solution(dl.AnyCover())
+
+	var decoded []string
+	if err := s.Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, []string{"d", "e", "a"}) {
+		t.Errorf("should be [d e a], got %v", decoded)
+	}
+	if payloads := s.Payloads(); !reflect.DeepEqual(payloads, []any{"d", "e", "a"}) {
+		t.Errorf("should be [d e a], got %v", payloads)
+	}
+	if labels := s.Labels(); !reflect.DeepEqual(labels, []string{"3", "4", "0"}) {
+		t.Errorf("should be [3 4 0], got %v", labels)
+	}
+
+	var wrong []int
+	if err := s.Decode(&wrong); err == nil {
+		t.Errorf("expected an error decoding strings into ints")
+	}
+	if err := s.Decode(decoded); err == nil {
+		t.Errorf("expected an error decoding into a non-pointer")
+	}
+}
+
+func TestSolutionLabels(t *testing.T) {
+	dl, err := NewLabeled(sushiItems, sushiOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels := dl.Solution(dl.AnyCover()).Labels()
+	if expected := []string{"local specials", "catch of the day", "chef's choice"}; !reflect.DeepEqual(labels, expected) {
+		t.Errorf("should be %q, got %q", expected, labels)
+	}
+}
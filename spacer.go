@@ -0,0 +1,249 @@
+package dancinglinks
+
+// An exact cover problem laid out in Knuth's single-array format.
+// Nodes 1 through n are the item headers, followed by the options'
+// nodes, with a spacer node before, between, and after the options.
+type spacer struct {
+	// Links of the list of items left to cover, indexed by item, with
+	// the list's anchor at 0.  Secondary items link to themselves.
+	llink []int
+	rlink []int
+
+	// For an item header, the number of options left covering the
+	// item; for an option's node, the item it covers; for a spacer,
+	// minus the number of options preceding it.
+	top []int
+
+	// Column links.  A spacer's ulink points to the first node of the
+	// option before it, and its dlink to the last node of the option
+	// after it.
+	ulink []int
+	dlink []int
+
+	// Color each node assigns to its item, as a positive number, or -1
+	// once the node's option is known to agree with the color
+	// committed to.
+	color []int
+
+	// Original index of each option laid out, in order.
+	options []int
+}
+
+// Lays out the items left to cover and the options still available,
+// keeping the linked engine's item and column order.
+func (dl *DLX) newSpacer() *spacer {
+	indices := make([]int, len(dl.items))
+	n := 0
+	for item := dl.itemHead.right; item != root; item = dl.items[item].right {
+		n++
+		indices[item] = n
+	}
+	primaryCount := n
+	for item := range dl.items {
+		if dl.items[item].secondary {
+			n++
+			indices[item] = n
+		}
+	}
+
+	// Room for the headers, the entries, and a spacer per option.
+	size := n + 1 + len(dl.entries) + len(dl.options) + 1
+	s := &spacer{
+		llink: make([]int, n+1),
+		rlink: make([]int, n+1),
+		top:   make([]int, n+1, size),
+		ulink: make([]int, n+1, size),
+		dlink: make([]int, n+1, size),
+		color: make([]int, n+1, size),
+	}
+	for i := 0; i <= n; i++ {
+		s.llink[i], s.rlink[i] = i, i
+		s.ulink[i], s.dlink[i] = i, i
+	}
+	for i := 1; i <= primaryCount; i++ {
+		s.llink[i] = i - 1
+		s.rlink[i-1] = i
+	}
+	s.llink[0] = primaryCount
+	s.rlink[primaryCount] = 0
+
+	spacer := s.appendNode(0, 0, 0, 0)
+	for option := range dl.options {
+		if !dl.optionLive(option) {
+			continue
+		}
+
+		first := len(s.top)
+		for e := range dl.row(option) {
+			entry := &dl.entries[e]
+			item := indices[entry.item]
+			// Reading colors as unsigned keeps them positive, leaving
+			// negative numbers free to mark agreement.
+			node := s.appendNode(item, s.ulink[item], item, int(uint32(entry.color)))
+			s.dlink[s.ulink[item]] = node
+			s.ulink[item] = node
+			s.top[item]++
+		}
+		s.dlink[spacer] = len(s.top) - 1
+		s.options = append(s.options, option)
+		spacer = s.appendNode(-len(s.options), first, 0, 0)
+	}
+
+	return s
+}
+
+func (s *spacer) appendNode(top, up, down, color int) int {
+	s.top = append(s.top, top)
+	s.ulink = append(s.ulink, up)
+	s.dlink = append(s.dlink, down)
+	s.color = append(s.color, color)
+	return len(s.top) - 1
+}
+
+// Yields every cover extending the given partial one, reporting
+// whether to go on.  The yielded slice is reused.
+func (s *spacer) search(dl *DLX, cover []int, yield func([]int) bool) bool {
+	if s.rlink[0] == 0 {
+		return yield(cover)
+	}
+
+	// Branch on the first item with the fewest remaining choices.
+	item := s.rlink[0]
+	for i := s.rlink[item]; i != 0; i = s.rlink[i] {
+		if s.top[i] < s.top[item] {
+			item = i
+		}
+	}
+
+	s.cover(item)
+	ok := true
+	for x := s.dlink[item]; x != item && ok; x = s.dlink[x] {
+		if !dl.visit() {
+			ok = false
+			break
+		}
+
+		for p := x + 1; p != x; {
+			if j := s.top[p]; j <= 0 {
+				p = s.ulink[p]
+			} else {
+				s.commit(p, j)
+				p++
+			}
+		}
+
+		ok = s.search(dl, append(cover, s.option(x)), yield)
+
+		for p := x - 1; p != x; {
+			if j := s.top[p]; j <= 0 {
+				p = s.dlink[p]
+			} else {
+				s.uncommit(p, j)
+				p--
+			}
+		}
+	}
+	s.uncover(item)
+	return ok
+}
+
+// The original index of the option a node belongs to.
+func (s *spacer) option(node int) int {
+	for s.top[node] > 0 {
+		node++
+	}
+	return s.options[-s.top[node]-1]
+}
+
+func (s *spacer) cover(item int) {
+	for p := s.dlink[item]; p != item; p = s.dlink[p] {
+		s.hide(p)
+	}
+	s.llink[s.rlink[item]] = s.llink[item]
+	s.rlink[s.llink[item]] = s.rlink[item]
+}
+
+func (s *spacer) uncover(item int) {
+	s.llink[s.rlink[item]] = item
+	s.rlink[s.llink[item]] = item
+	for p := s.ulink[item]; p != item; p = s.ulink[p] {
+		s.unhide(p)
+	}
+}
+
+// Removes the other nodes of a node's option from their columns.
+func (s *spacer) hide(p int) {
+	for q := p + 1; q != p; {
+		x, u, d := s.top[q], s.ulink[q], s.dlink[q]
+		switch {
+		case x <= 0:
+			q = u
+		case s.color[q] < 0:
+			q++
+		default:
+			s.dlink[u] = d
+			s.ulink[d] = u
+			s.top[x]--
+			q++
+		}
+	}
+}
+
+func (s *spacer) unhide(p int) {
+	for q := p - 1; q != p; {
+		x, u, d := s.top[q], s.ulink[q], s.dlink[q]
+		switch {
+		case x <= 0:
+			q = d
+		case s.color[q] < 0:
+			q--
+		default:
+			s.dlink[u] = q
+			s.ulink[d] = q
+			s.top[x]++
+			q--
+		}
+	}
+}
+
+// Covers an item of the option being chosen, or if the option colors
+// it, rules out the options disagreeing on its color.
+func (s *spacer) commit(p, item int) {
+	switch {
+	case s.color[p] == 0:
+		s.cover(item)
+	case s.color[p] > 0:
+		s.purify(p)
+	}
+}
+
+func (s *spacer) uncommit(p, item int) {
+	switch {
+	case s.color[p] == 0:
+		s.uncover(item)
+	case s.color[p] > 0:
+		s.unpurify(p)
+	}
+}
+
+func (s *spacer) purify(p int) {
+	c, item := s.color[p], s.top[p]
+	for q := s.dlink[item]; q != item; q = s.dlink[q] {
+		if s.color[q] == c {
+			s.color[q] = -1
+		} else {
+			s.hide(q)
+		}
+	}
+}
+
+func (s *spacer) unpurify(p int) {
+	c, item := s.color[p], s.top[p]
+	for q := s.ulink[item]; q != item; q = s.ulink[q] {
+		if s.color[q] < 0 {
+			s.color[q] = c
+		} else {
+			s.unhide(q)
+		}
+	}
+}
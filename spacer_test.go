@@ -0,0 +1,83 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSpacerEngine(t *testing.T) {
+	const A, B = 1, 2
+	colored := func() *DLX {
+		return NewColored(3, 2, [][]ColoredItem{
+			[]ColoredItem{{0, 0}, {1, 0}, {3, 0}, {4, A}},
+			[]ColoredItem{{0, 0}, {2, 0}, {3, A}, {4, 0}},
+			[]ColoredItem{{0, 0}, {3, B}},
+			[]ColoredItem{{1, 0}, {3, A}},
+			[]ColoredItem{{2, 0}, {4, B}},
+		})
+	}
+
+	negative := func() *DLX {
+		return NewColored(2, 1, [][]ColoredItem{
+			[]ColoredItem{{0, 0}, {2, -1}},
+			[]ColoredItem{{1, 0}, {2, -2}},
+			[]ColoredItem{{1, 0}, {2, -1}},
+		})
+	}
+
+	forced := func() *DLX {
+		dl := classicDuplicates.toDLX()
+		dl.ForceOptions(0)
+		return dl
+	}
+
+	disabled := func() *DLX {
+		dl := classicDuplicates.toDLX()
+		dl.DisableOption(4)
+		return dl
+	}
+
+	for _, setup := range []func() *DLX{
+		classic.toDLX,
+		classicDuplicates.toDLX,
+		impossible.toDLX,
+		trivial.toDLX,
+		colored,
+		negative,
+		forced,
+		disabled,
+	} {
+		want := setup().AllCovers()
+
+		dl := setup()
+		dl.SetEngine(EngineSpacer)
+		if got := dl.AllCovers(); !reflect.DeepEqual(got, want) {
+			t.Errorf("spacer engine should find %v, found %v", want, got)
+		}
+		if count := dl.CountSolutions(); count.Int64() != int64(len(want)) {
+			t.Errorf("spacer engine should count %d covers, counted %v", len(want), count)
+		}
+	}
+
+	itemCount, options := latinSquare(4)
+	dl := New(itemCount, options, WithEngine(EngineSpacer))
+	if count := dl.CountSolutions(); count.Int64() != 576 {
+		t.Errorf("should count 576 latin squares, counted %v", count)
+	}
+	if count, reached := dl.CountSolutionsUpTo(10); count != 10 || !reached {
+		t.Errorf("should stop at 10 latin squares, counted %d", count)
+	}
+
+	dl.SetNodeLimit(10)
+	dl.AllCovers()
+	if dl.Err() != ErrNodeLimit {
+		t.Errorf("should respect the node limit, got %v", dl.Err())
+	}
+}
+
+func BenchmarkSpacerLatinSquare(b *testing.B) {
+	itemCount, options := latinSquare(25)
+	for i := 0; i < b.N; i++ {
+		New(itemCount, options, WithEngine(EngineSpacer)).AnyCover()
+	}
+}
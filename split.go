@@ -0,0 +1,50 @@
+package dancinglinks
+
+// Split partitions the search by the options covering the item it
+// would branch on first, returning an independent copy of the DLX for
+// each of them with that option forced, which Selected reports.  Each
+// solution belongs to exactly one part, so that the parts can be
+// solved separately, e.g. on different goroutines or machines, and
+// their counts add up to the total.  The copies keep all settings; in
+// particular, a depth limit, which does not count forced options,
+// applies to each part afresh.
+//
+// If some item cannot be covered at all, there are no parts.  If
+// nothing is left to cover, the only part is a plain copy.
+func (dl *DLX) Split() []*DLX {
+	if len(dl.UncoverableItems()) > 0 {
+		return []*DLX{}
+	}
+
+	item := dl.nextItem()
+	if item == root {
+		return []*DLX{dl.Clone()}
+	}
+
+	parts := []*DLX{}
+	for _, option := range dl.RemainingChoices(item) {
+		part := dl.Clone()
+		if part.forceRepresentative(option) {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// Forces an option along with its predecessors in its symmetry group,
+// which every representative solution selecting the option selects as
+// well, reporting false if they conflict.
+func (dl *DLX) forceRepresentative(option int) bool {
+	for option != -1 && !intSliceContains(dl.selected, option) {
+		if !dl.optionLive(option) {
+			return false
+		}
+		dl.ForceOptions(option)
+
+		if dl.symmetryPrev == nil {
+			break
+		}
+		option = dl.symmetryPrev[option]
+	}
+	return true
+}
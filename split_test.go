@@ -0,0 +1,52 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	collapsed := func() *DLX {
+		dl := classicDuplicates.toDLX()
+		dl.CollapseDuplicates()
+		return dl
+	}
+
+	latin := func() *DLX {
+		itemCount, options := latinSquare(4)
+		return New(itemCount, options)
+	}
+
+	for _, setup := range []func() *DLX{
+		classic.toDLX,
+		classicDuplicates.toDLX,
+		impossible.toDLX,
+		trivial.toDLX,
+		collapsed,
+		latin,
+	} {
+		dl := setup()
+		want := dl.AllCovers()
+		sortSequences(want)
+
+		// Together with their forced options, the parts' covers are
+		// exactly the covers of the whole.
+		got := [][]int{}
+		for _, part := range dl.Split() {
+			for _, cover := range part.AllCovers() {
+				got = append(got, append(cover, part.Selected()...))
+			}
+		}
+		sortSequences(got)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("parts should have covers %v, got %v", want, got)
+		}
+	}
+
+	if parts := latin().Split(); len(parts) != 4 {
+		t.Errorf("should split into 4 latin squares, got %d", len(parts))
+	}
+	if parts := New(3, [][]int{{0}, {0, 1}}).Split(); len(parts) != 0 {
+		t.Errorf("should not split with item 2 uncoverable, got %d parts", len(parts))
+	}
+}
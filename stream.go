@@ -0,0 +1,71 @@
+package dancinglinks
+
+import "fmt"
+
+// A Stream sets up a problem one option at a time, linking each option
+// into the structure as soon as it is added, so that a generator never
+// needs to hold all the options at once.  Seal hands over the finished
+// DLX.
+type Stream struct {
+	dl *DLX
+
+	// The most entries the stream can hold, or 0 if unlimited.
+	limit int
+}
+
+// NewStream starts setting up a colored exact cover problem, as with
+// NewColored, with no options yet.  For a plain exact cover problem,
+// leave secondaryCount at zero.
+func NewStream(primaryCount, secondaryCount int) *Stream {
+	return &Stream{dl: newDLX(primaryCount, secondaryCount, nil, nil)}
+}
+
+// AddOption adds an option covering the given items, which the stream
+// does not retain, and returns its index.
+func (s *Stream) AddOption(items ...int) int {
+	index := s.newOption(len(items))
+	for _, item := range items {
+		s.dl.appendEntry(item, index)
+	}
+	return index
+}
+
+// AddColoredOption adds an option assigning colors to the secondary
+// items it covers, as with NewColored, and returns its index.
+func (s *Stream) AddColoredOption(items ...ColoredItem) int {
+	index := s.newOption(len(items))
+	for _, item := range items {
+		s.dl.appendEntry(item.Item, index)
+		if s.dl.items[item.Item].secondary {
+			s.dl.entries[len(s.dl.entries)-1].color = checkColor(item.Color)
+		}
+	}
+	return index
+}
+
+// Adds an option without any entries yet, making sure the given number
+// of entries fit, and returns its index.
+func (s *Stream) newOption(entryCount int) int {
+	if s.dl == nil {
+		panic("dancinglinks: option added to a sealed stream")
+	}
+	if s.limit > 0 && len(s.dl.entries)+entryCount > s.limit {
+		panic(fmt.Sprintf("dancinglinks: stream is full at %d entries", s.limit))
+	}
+	index := len(s.dl.options)
+	s.dl.options = append(s.dl.options, -1)
+	s.dl.hidden = append(s.dl.hidden, 0)
+	return index
+}
+
+// Seal finishes setting up the problem, configured by the given
+// settings, and returns it.  No options can be added to the stream
+// afterwards, though they can still be added to the DLX itself.
+func (s *Stream) Seal(settings ...Setting) *DLX {
+	dl := s.dl
+	s.dl = nil
+	for _, setting := range settings {
+		setting(dl)
+	}
+	return dl
+}
@@ -0,0 +1,35 @@
+package dancinglinks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	s := NewStream(classicDuplicates.itemCount, 0)
+	for i, option := range classicDuplicates.options {
+		if index := s.AddOption(option...); index != i {
+			t.Errorf("option %v should have index %d, got %d", option, i, index)
+		}
+	}
+	dl := s.Seal()
+	if !dl.Equal(classicDuplicates.toDLX()) {
+		t.Errorf("should set up the same problem as New, got %v", dl)
+	}
+	testExample(t, dl.AllSolutions(), classicDuplicates.solution)
+
+	s = NewStream(2, 1)
+	s.AddColoredOption(ColoredItem{0, 0}, ColoredItem{2, 1})
+	s.AddColoredOption(ColoredItem{1, 0}, ColoredItem{2, 1})
+	s.AddColoredOption(ColoredItem{1, 0}, ColoredItem{2, 2})
+	if covers := s.Seal().AllCovers(); !reflect.DeepEqual(covers, [][]int{{0, 1}}) {
+		t.Errorf("should be [[0 1]], got %v", covers)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("should panic when adding to a sealed stream")
+		}
+	}()
+	s.AddOption(0)
+}
@@ -0,0 +1,44 @@
+package dancinglinks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String describes the problem: its items and options, which options
+// are forced, disabled, or ruled out, and how much is left to cover.
+func (dl *DLX) String() string {
+	b := &strings.Builder{}
+
+	secondary := 0
+	for _, item := range dl.items {
+		if item.secondary {
+			secondary++
+		}
+	}
+	fmt.Fprintf(b, "%d items (%d secondary), %d options\n", len(dl.items), secondary, len(dl.options))
+
+	for i, first := range dl.options {
+		fmt.Fprintf(b, "%s:", dl.OptionLabel(i))
+		for e := range dl.row(i) {
+			entry := &dl.entries[e]
+			fmt.Fprintf(b, " %s", dl.ItemLabel(int(entry.item)))
+			if entry.color != 0 {
+				fmt.Fprintf(b, ":%d", entry.color)
+			}
+		}
+
+		switch {
+		case intSliceContains(dl.selected, i):
+			b.WriteString(" (forced)")
+		case intSliceContains(dl.disabled, i):
+			b.WriteString(" (disabled)")
+		case first != -1 && !dl.optionLive(i):
+			b.WriteString(" (ruled out)")
+		}
+		b.WriteByte('\n')
+	}
+
+	fmt.Fprintf(b, "%d items left to cover, %d options available", dl.uncoveredCount(), len(dl.ActiveOptions()))
+	return b.String()
+}
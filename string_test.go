@@ -0,0 +1,31 @@
+package dancinglinks
+
+import (
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	dl := classic.toDLX()
+	dl.ForceOptions(0)
+	dl.DisableOption(4)
+
+	expected := `7 items (0 secondary), 6 options
+0: 2 4 (forced)
+1: 0 3 6
+2: 1 2 5 (ruled out)
+3: 0 3 5
+4: 1 6 (disabled)
+5: 3 4 6 (ruled out)
+5 items left to cover, 2 options available`
+	if s := dl.String(); s != expected {
+		t.Errorf("should be\n%s\ngot\n%s", expected, s)
+	}
+
+	dl = NewColored(1, 1, [][]ColoredItem{{{0, 0}, {1, 2}}})
+	expected = `2 items (1 secondary), 1 options
+0: 0 1:2
+1 items left to cover, 1 options available`
+	if s := dl.String(); s != expected {
+		t.Errorf("should be\n%s\ngot\n%s", expected, s)
+	}
+}
@@ -14,28 +14,35 @@ func block(row, column int) int {
 }
 
 func solve(board [][]int) {
+	// Each value must appear once in every row, column, and block, and
+	// each cell must hold one value.
+	groups := dancinglinks.NewItemGroups().
+		Add("row", 9, 9).
+		Add("column", 9, 9).
+		Add("block", 9, 9).
+		Add("cell", 9, 9)
+
 	options := make([][]int, 9*9*9)
-	sudokuEntries := make([]sudokuEntry, 9*9*9)
+	payloads := make([]any, 9*9*9)
 
 	for row := 0; row < 9; row++ {
 		for column := 0; column < 9; column++ {
 			for value := 0; value < 9; value++ {
-				entry := sudokuEntry{row, column, value}
-
 				option := []int{
-					0*9*9 + 9*value + row,
-					1*9*9 + 9*value + column,
-					2*9*9 + 9*value + block(row, column),
-					3*9*9 + 9*row + column,
+					groups.Item("row", row, value),
+					groups.Item("column", column, value),
+					groups.Item("block", block(row, column), value),
+					groups.Item("cell", row, column),
 				}
 
-				options[9*9*row + 9*column + value] = option
-				sudokuEntries[9*9*row + 9*column + value] = entry
+				options[9*9*row+9*column+value] = option
+				payloads[9*9*row+9*column+value] = sudokuEntry{row, column, value}
 			}
 		}
 	}
 
-	dl := dancinglinks.New(4*9*9, options)
+	dl := dancinglinks.NewWithPayloads(groups.Count(), options, payloads)
+	dl.SetItemGroups(groups)
 
 	for row := 0; row < 9; row++ {
 		for column := 0; column < 9; column++ {
@@ -45,10 +52,9 @@ func solve(board [][]int) {
 		}
 	}
 
-	cover := dl.AnySolution()
+	cover := dl.AnyCover()
 
-	for _, option := range cover {
-		entry := sudokuEntries[option]
+	for _, entry := range dancinglinks.Payloads[sudokuEntry](dl, cover) {
 		fmt.Printf("row %d, column %d: value %d\n", entry.row+1, entry.column+1, entry.value+1)
 		board[entry.row][entry.column] = entry.value + 1
 	}
@@ -58,7 +64,7 @@ func solve(board [][]int) {
 	}
 
 	count := 0
-	dl.GenerateSolutions(func([]int) bool {
+	dl.GenerateCovers(func([]int) bool {
 		count++
 		fmt.Printf("\r%d solutions found ", count)
 		return true
@@ -0,0 +1,101 @@
+package dancinglinks
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AddSymmetry declares a group of interchangeable options, meaning
+// that any solution selecting some k of them remains a solution after
+// swapping those for the first k options of the group.  The solver
+// then only reports the representative solutions, i.e. those whose
+// selected options from the group form a prefix of it.  A typical use
+// is a group of duplicate options, of which only the first is ever
+// selected.  Each option belongs to at most one group; declaring a
+// new group overrides previous declarations for its options.
+func (dl *DLX) AddSymmetry(options ...int) {
+	if dl.symmetryPrev == nil {
+		dl.symmetryPrev = make([]int, len(dl.options))
+		for i := range dl.symmetryPrev {
+			dl.symmetryPrev[i] = -1
+		}
+	}
+
+	for i, option := range options {
+		if i == 0 {
+			dl.symmetryPrev[option] = -1
+		} else {
+			dl.symmetryPrev[option] = options[i-1]
+		}
+	}
+}
+
+// CollapseDuplicates declares each group of identical options, i.e.
+// options covering the same items with the same colors, as a symmetry
+// group, so that covers differing only in which duplicate was picked
+// are reported once, using the lowest-index duplicates.  Like
+// AddSymmetry, it overrides previous declarations for the options
+// involved, and does not apply to options added afterwards.
+func (dl *DLX) CollapseDuplicates() {
+	groups := map[string][]int{}
+	keys := []string{}
+	for option := range dl.options {
+		pairs := [][2]int{}
+		for e := range dl.row(option) {
+			entry := &dl.entries[e]
+			pairs = append(pairs, [2]int{int(entry.item), int(entry.color)})
+		}
+		sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+
+		key := fmt.Sprint(pairs)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], option)
+	}
+
+	for _, key := range keys {
+		if group := groups[key]; len(group) > 1 {
+			dl.AddSymmetry(group...)
+		}
+	}
+}
+
+// Reports whether, having just chosen the given option on top of the
+// given path, a representative solution may still be reached: the
+// option's predecessor in its group must already be selected or still
+// be available.
+func (dl *DLX) symmetryAllows(option int, path []Step) bool {
+	if dl.symmetryPrev == nil || dl.symmetryPrev[option] == -1 {
+		return true
+	}
+	prev := dl.symmetryPrev[option]
+	return dl.optionLive(prev) || dl.isSelected(prev, path)
+}
+
+// Reports whether a complete solution is the representative of its
+// symmetry class.
+func (dl *DLX) symmetryHolds(path []Step) bool {
+	if dl.symmetryPrev == nil {
+		return true
+	}
+	for _, step := range path {
+		if prev := dl.symmetryPrev[step.Option]; prev != -1 && !dl.isSelected(prev, path) {
+			return false
+		}
+	}
+	return true
+}
+
+// Whether the option has been forced or chosen along the path.
+func (dl *DLX) isSelected(option int, path []Step) bool {
+	if intSliceContains(dl.selected, option) {
+		return true
+	}
+	for _, step := range path {
+		if step.Option == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package dancinglinks
+
+// A Template is a base problem built once, against which many
+// instances differing only in their forced options are solved in
+// turn, such as sudoku puzzles against the generic sudoku matrix.
+// Switching to another instance merely unforces the previous one's
+// options, which takes time proportional to the options they ruled
+// out, instead of rebuilding the structure.
+type Template struct {
+	dl *DLX
+
+	// The number of options the base problem itself forces.
+	base int
+}
+
+// NewTemplate makes a template of the DLX, as it is set up now,
+// including its forced options and settings.  The DLX then belongs to
+// the template; to solve instances on several goroutines, give each
+// its own template of a Clone.
+func NewTemplate(dl *DLX) *Template {
+	return &Template{dl: dl, base: len(dl.selected)}
+}
+
+// Instance sets up the instance forcing the given options on top of
+// the base problem's, undoing the previous instance, and returns the
+// DLX to solve it with.  The DLX is only good until the next call,
+// and any Search on it must be finished or closed by then.  As with
+// ForceOptionsChecked, an error means that the options cannot all be
+// forced, in which case the instance has no solutions and the base
+// problem is left as it is.
+func (t *Template) Instance(options ...int) (*DLX, error) {
+	t.dl.unforceDownTo(t.base)
+	if err := t.dl.ForceOptionsChecked(options...); err != nil {
+		return nil, err
+	}
+	return t.dl, nil
+}
@@ -0,0 +1,43 @@
+package dancinglinks
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTemplate(t *testing.T) {
+	itemCount, options := latinSquare(4)
+	base := New(itemCount, options)
+
+	// The first row of the square reads 0 1 2 3.
+	base.ForceOptions(0, 5, 10, 15)
+	template := NewTemplate(base)
+
+	for _, instance := range [][]int{
+		{},
+		{16 + 1},
+		{16 + 1, 32 + 3},
+		{},
+	} {
+		dl, err := template.Instance(instance...)
+		if err != nil {
+			t.Fatalf("instance %v: unexpected error %v", instance, err)
+		}
+
+		fresh := New(itemCount, options)
+		fresh.ForceOptions(0, 5, 10, 15)
+		fresh.ForceOptions(instance...)
+		if got, want := dl.CountSolutions(), fresh.CountSolutions(); got.Cmp(want) != 0 {
+			t.Errorf("instance %v: should count %v solutions, counted %v", instance, want, got)
+		}
+	}
+
+	// A second 1 in the second row clashes with the first.
+	var conflict *ConflictError
+	if _, err := template.Instance(16+1, 16+4+1); !errors.As(err, &conflict) {
+		t.Errorf("should report a conflict, got %v", err)
+	}
+	if selected := template.dl.Selected(); len(selected) != 4 {
+		t.Errorf("should be back to the base problem, got forced options %v", selected)
+	}
+}
@@ -0,0 +1,64 @@
+package dancinglinks
+
+import (
+	"math/rand"
+)
+
+// A policy for choosing the next item to cover among the items tied
+// for the fewest remaining choices.
+type TieBreak int
+
+const (
+	// Choose the tied item with the lowest index, or the one coming
+	// first in the order set by SetItemOrder.  This is the default.
+	TieLowestIndex TieBreak = iota
+
+	// Choose the tied item whose remaining choices changed most
+	// recently.
+	TieLastTouched
+
+	// Choose a tied item uniformly at random.
+	TieRandom
+
+	// Let a callback, set with SetTieBreakFunc, choose.
+	TieFunc
+)
+
+// SetTieBreak sets the policy for choosing among items tied for the
+// fewest remaining choices.  Unless seeded with Randomize, TieRandom
+// uses a fixed seed.  Searches breaking ties at random cannot be
+// resumed from a checkpoint.
+func (dl *DLX) SetTieBreak(policy TieBreak) {
+	dl.tieBreak = policy
+	dl.buckets = nil
+}
+
+// SetTieBreakFunc makes the solver call choose to pick among the
+// items tied for the fewest remaining choices.  The callback gets
+// the indices of the tied items, in increasing order, and returns one
+// of them.
+func (dl *DLX) SetTieBreakFunc(choose func(items []int) int) {
+	dl.tieFunc = choose
+	dl.tieBreak = TieFunc
+	dl.buckets = nil
+}
+
+// The source of randomness for breaking ties, set up with a fixed seed
+// if need be.
+func (dl *DLX) random() *rand.Rand {
+	if dl.tieRng == nil {
+		dl.tieRng = rand.New(rand.NewSource(1))
+	}
+	return dl.tieRng
+}
+
+// The items left to cover with the given number of remaining choices.
+func (dl *DLX) tiedItems(choices int) []int {
+	items := []int{}
+	for item := dl.itemHead.right; item != root; item = dl.items[item].right {
+		if dl.items[item].choices == choices {
+			items = append(items, item)
+		}
+	}
+	return items
+}
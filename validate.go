@@ -0,0 +1,71 @@
+package dancinglinks
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNegativeItemCount is wrapped by errors reporting a negative
+	// number of items.
+	ErrNegativeItemCount = errors.New("dancinglinks: negative item count")
+
+	// ErrTooManyItems is wrapped by errors reporting more items than a
+	// DLX can hold.
+	ErrTooManyItems = errors.New("dancinglinks: too many items")
+
+	// ErrItemOutOfRange is wrapped by errors reporting an option that
+	// covers an item index outside of [0, itemCount).
+	ErrItemOutOfRange = errors.New("dancinglinks: item out of range")
+
+	// ErrDuplicateItem is wrapped by errors reporting an option that
+	// covers the same item more than once.
+	ErrDuplicateItem = errors.New("dancinglinks: item covered twice by an option")
+)
+
+// NewChecked is like New, but first validates the input, returning a
+// descriptive error instead of building a broken structure.  Every
+// item index must lie in [0, itemCount), and no option may cover the
+// same item twice.  The errors wrap ErrNegativeItemCount,
+// ErrTooManyItems, ErrItemOutOfRange, or ErrDuplicateItem.
+func NewChecked(itemCount int, options [][]int) (*DLX, error) {
+	if err := validate(itemCount, options); err != nil {
+		return nil, err
+	}
+	return New(itemCount, options), nil
+}
+
+// MustNew is like NewChecked, but panics if the input is invalid.
+func MustNew(itemCount int, options [][]int) *DLX {
+	dl, err := NewChecked(itemCount, options)
+	if err != nil {
+		panic(err)
+	}
+	return dl
+}
+
+func validate(itemCount int, options [][]int) error {
+	if itemCount < 0 {
+		return fmt.Errorf("%w: %d", ErrNegativeItemCount, itemCount)
+	}
+	if itemCount > maxEntries {
+		return fmt.Errorf("%w: %d exceeds the limit of %d", ErrTooManyItems, itemCount, maxEntries)
+	}
+
+	seen := make([]int, itemCount)
+	for option, items := range options {
+		for _, item := range items {
+			if item < 0 || item >= itemCount {
+				return fmt.Errorf("%w: option %d covers item %d, outside of [0, %d)", ErrItemOutOfRange, option, item, itemCount)
+			}
+
+			// Mark items with option+1, so that the zero value means
+			// unseen.
+			if seen[item] == option+1 {
+				return fmt.Errorf("%w: option %d covers item %d more than once", ErrDuplicateItem, option, item)
+			}
+			seen[item] = option + 1
+		}
+	}
+	return nil
+}
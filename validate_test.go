@@ -0,0 +1,40 @@
+package dancinglinks
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewChecked(t *testing.T) {
+	if _, err := NewChecked(classic.itemCount, classic.options); err != nil {
+		t.Errorf("classic example should be valid, got %v", err)
+	}
+
+	for _, test := range []struct {
+		options [][]int
+		err     error
+	}{
+		{[][]int{{0, 3}}, ErrItemOutOfRange},
+		{[][]int{{0}, {-1}}, ErrItemOutOfRange},
+		{[][]int{{1, 2, 1}}, ErrDuplicateItem},
+	} {
+		if _, err := NewChecked(3, test.options); !errors.Is(err, test.err) {
+			t.Errorf("options %v should be rejected with %v, got %v", test.options, test.err, err)
+		}
+	}
+
+	if _, err := NewChecked(-1, nil); !errors.Is(err, ErrNegativeItemCount) {
+		t.Errorf("negative item count should be rejected with %v, got %v", ErrNegativeItemCount, err)
+	}
+}
+
+func TestMustNew(t *testing.T) {
+	testExample(t, MustNew(classic.itemCount, classic.options).AllSolutions(), classic.solution)
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrItemOutOfRange) {
+			t.Errorf("should panic with %v, got %v", ErrItemOutOfRange, err)
+		}
+	}()
+	MustNew(1, [][]int{{1}})
+}
@@ -0,0 +1,79 @@
+package dancinglinks
+
+import (
+	"fmt"
+)
+
+// CoverError reports why a selection of options is not an exact
+// cover.
+type CoverError struct {
+	// Primary items covered by none of the options.
+	Uncovered []int
+
+	// Items covered by more than one option, or for colored secondary
+	// items, by options disagreeing on the color.
+	Overcovered []int
+}
+
+func (e *CoverError) Error() string {
+	switch {
+	case len(e.Uncovered) == 0:
+		return fmt.Sprintf("dancinglinks: items %v covered more than once", e.Overcovered)
+	case len(e.Overcovered) == 0:
+		return fmt.Sprintf("dancinglinks: items %v not covered", e.Uncovered)
+	}
+	return fmt.Sprintf("dancinglinks: items %v not covered and items %v covered more than once", e.Uncovered, e.Overcovered)
+}
+
+// Verify checks that the given options, together with the forced
+// ones, form an exact cover, i.e. that they are a cover as yielded by
+// GenerateCovers.  It returns a *CoverError listing the offending
+// items if they do not, and an error if some option is out of range,
+// disabled, or listed twice.
+func (dl *DLX) Verify(options []int) error {
+	seen := make([]bool, len(dl.options))
+	for _, option := range options {
+		switch {
+		case option < 0 || option >= len(dl.options):
+			return fmt.Errorf("dancinglinks: option %d out of range [0, %d)", option, len(dl.options))
+		case intSliceContains(dl.disabled, option):
+			return fmt.Errorf("dancinglinks: option %d is disabled", option)
+		case seen[option]:
+			return fmt.Errorf("dancinglinks: option %d selected twice", option)
+		}
+		seen[option] = true
+	}
+
+	// For each item, how often it is covered, and the color it was
+	// first covered with.
+	counts := make([]int, len(dl.items))
+	colors := make([]int32, len(dl.items))
+	overcovered := make([]bool, len(dl.items))
+	for _, option := range append(append([]int{}, dl.selected...), options...) {
+		for e := range dl.row(option) {
+			entry := &dl.entries[e]
+			index := int(entry.item)
+			counts[index]++
+			switch {
+			case counts[index] == 1:
+				colors[index] = entry.color
+			case entry.color == 0 || entry.color != colors[index]:
+				overcovered[index] = true
+			}
+		}
+	}
+
+	e := &CoverError{}
+	for index, item := range dl.items {
+		if counts[index] == 0 && !item.secondary {
+			e.Uncovered = append(e.Uncovered, index)
+		}
+		if overcovered[index] {
+			e.Overcovered = append(e.Overcovered, index)
+		}
+	}
+	if e.Uncovered != nil || e.Overcovered != nil {
+		return e
+	}
+	return nil
+}
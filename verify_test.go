@@ -0,0 +1,54 @@
+package dancinglinks
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	dl := classic.toDLX()
+	for _, cover := range dl.AllCovers() {
+		if err := dl.Verify(cover); err != nil {
+			t.Errorf("Verify(%v): unexpected error %v", cover, err)
+		}
+	}
+
+	var coverErr *CoverError
+	err := dl.Verify([]int{0, 3, 5})
+	if !errors.As(err, &coverErr) {
+		t.Fatalf("expected a *CoverError, got %v", err)
+	}
+	if !reflect.DeepEqual(coverErr.Uncovered, []int{1}) || !reflect.DeepEqual(coverErr.Overcovered, []int{3, 4}) {
+		t.Errorf("expected uncovered [1] and overcovered [3 4], got %v and %v", coverErr.Uncovered, coverErr.Overcovered)
+	}
+
+	for _, cover := range [][]int{[]int{0, 3, 3}, []int{0, 3, 6}} {
+		if err := dl.Verify(cover); err == nil || errors.As(err, &coverErr) {
+			t.Errorf("Verify(%v): expected a plain error, got %v", cover, err)
+		}
+	}
+
+	// Forced options count towards the cover.
+	dl.ForceOptions(0)
+	if err := dl.Verify([]int{3, 4}); err != nil {
+		t.Errorf("Verify with forced option: unexpected error %v", err)
+	}
+	if err := dl.Verify([]int{0, 3, 4}); !errors.As(err, &coverErr) {
+		t.Errorf("expected a *CoverError repeating a forced option, got %v", err)
+	}
+}
+
+func TestVerifyColored(t *testing.T) {
+	dl := NewColored(1, 1, [][]ColoredItem{
+		[]ColoredItem{ColoredItem{0, 0}, ColoredItem{1, 1}},
+		[]ColoredItem{ColoredItem{1, 1}},
+		[]ColoredItem{ColoredItem{1, 2}},
+	})
+	if err := dl.Verify([]int{0, 1}); err != nil {
+		t.Errorf("expected agreeing colors to verify, got %v", err)
+	}
+	if err := dl.Verify([]int{0, 2}); err == nil {
+		t.Errorf("expected disagreeing colors to fail")
+	}
+}
@@ -0,0 +1,86 @@
+package dancinglinks
+
+// A Visitor follows the depth-first traversal of the search tree.
+type Visitor interface {
+	// Enter is called when the search takes a step, before exploring
+	// what lies beyond it.  Returning false skips that part of the
+	// search tree.
+	Enter(step Step) bool
+
+	// Leave is called when the search takes back a step that Enter
+	// returned true for.
+	Leave(step Step)
+
+	// Solution is called for every solution found, with the steps
+	// leading to it.  The slice is only valid during the call.
+	Solution(solution []Step)
+}
+
+// Walk runs a search, reporting its traversal of the search tree to
+// v.  Like GenerateSolutions, it returns false if the search was cut
+// short by a limit, which Err reports.
+func (dl *DLX) Walk(v Visitor) bool {
+	if !dl.checkCoverable() {
+		return true
+	}
+
+	w := &walker{dl: dl, visitor: v, path: []Step{}}
+	w.walk(-1)
+	return dl.searchErr() == nil
+}
+
+// The state of a walk.
+type walker struct {
+	dl      *DLX
+	visitor Visitor
+	path    []Step
+}
+
+// Explores the search tree below the node reached by a move involving
+// the given option (or -1 at the root), reporting whether to go on.
+func (w *walker) walk(after int) bool {
+	dl := w.dl
+	st := &stage{}
+	if !dl.branch(after, st) {
+		if dl.symmetryHolds(w.path) {
+			w.visitor.Solution(w.path)
+		}
+		return true
+	}
+	st.remaining = dl.uncoveredCount()
+
+	for _, move := range st.choices {
+		// Past the depth limit, there is no room left to cover the
+		// remaining items.
+		if dl.depthLimit > 0 && len(w.path) >= dl.depthLimit {
+			dl.depthCutoff = true
+			return true
+		}
+		if !dl.visit() {
+			return false
+		}
+
+		dl.move(move)
+
+		ok := true
+		switch {
+		case move < 0:
+			ok = w.walk(^move)
+		case !dl.symmetryAllows(move, w.path):
+		default:
+			step := dl.step(st, move, len(w.path))
+			if w.visitor.Enter(step) {
+				w.path = append(w.path, step)
+				ok = w.walk(move)
+				w.path = w.path[:len(w.path)-1]
+				w.visitor.Leave(step)
+			}
+		}
+
+		dl.unmove(move)
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
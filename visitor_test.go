@@ -0,0 +1,52 @@
+package dancinglinks
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// Logs the traversal, skipping steps selecting the given option.
+type logVisitor struct {
+	skip   int
+	events []string
+}
+
+func (v *logVisitor) Enter(step Step) bool {
+	v.events = append(v.events, fmt.Sprintf("enter %d", step.Option))
+	return step.Option != v.skip
+}
+
+func (v *logVisitor) Leave(step Step) {
+	v.events = append(v.events, fmt.Sprintf("leave %d", step.Option))
+}
+
+func (v *logVisitor) Solution(solution []Step) {
+	v.events = append(v.events, fmt.Sprintf("solution %v", coverOf(solution)))
+}
+
+func TestWalk(t *testing.T) {
+	v := &logVisitor{skip: -1}
+	if !classic.toDLX().Walk(v) {
+		t.Errorf("walk should not be cut short")
+	}
+	expected := []string{
+		"enter 1", "enter 2", "leave 2", "leave 1",
+		"enter 3", "enter 4", "enter 0", "solution [3 4 0]", "leave 0", "leave 4", "leave 3",
+	}
+	if !reflect.DeepEqual(v.events, expected) {
+		t.Errorf("should be %q, got %q", expected, v.events)
+	}
+
+	v = &logVisitor{skip: 3}
+	classic.toDLX().Walk(v)
+	expected = []string{"enter 1", "enter 2", "leave 2", "leave 1", "enter 3"}
+	if !reflect.DeepEqual(v.events, expected) {
+		t.Errorf("should be %q, got %q", expected, v.events)
+	}
+
+	// The walk leaves the DLX intact.
+	dl := classicDuplicates.toDLX()
+	dl.Walk(&logVisitor{skip: -1})
+	testExample(t, dl.AllSolutions(), classicDuplicates.solution)
+}
@@ -0,0 +1,267 @@
+package dancinglinks
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+)
+
+// A zero-suppressed decision diagram (ZDD) compactly representing a
+// family of exact covers, built by memoizing the dancing links search
+// on the set of items left to cover (Knuth's "DXZ").  Each node
+// stands for the family of covers that either select the node's
+// option and continue with its `hi` branch, or skip it and continue
+// with its `lo` branch.  Subproblems with the same remaining items
+// are shared, so the diagram can represent astronomically many covers
+// in little space.
+type ZDD struct {
+	// Nodes of the diagram.  The first two are the terminal nodes
+	// standing for the empty family and for the family consisting of
+	// just the empty cover.
+	nodes []zddNode
+
+	// Index of the root node.
+	root int
+
+	// Number of covers represented by each node, computed on demand.
+	counts []*big.Int
+}
+
+// A ZDD node.
+type zddNode struct {
+	option int
+	lo, hi int
+}
+
+// Indices of the terminal ZDD nodes.
+const (
+	zddBottom = 0
+	zddTop    = 1
+)
+
+// ZDD builds a decision diagram representing all exact covers, in
+// the same form as those of GenerateCovers.  Symmetry declarations
+// are ignored.  If the node limit is exceeded, ZDD returns nil and Err
+// returns ErrNodeLimit.
+func (dl *DLX) ZDD() *ZDD {
+	dl.resetSearch()
+
+	b := &zddBuilder{
+		dl: dl,
+		zdd: &ZDD{
+			nodes: []zddNode{{option: -1}, {option: -1}},
+		},
+		unique: map[zddNode]int{},
+		memo:   map[string]int{},
+	}
+
+	for _, item := range dl.items {
+		if item.secondary {
+			b.secondary = true
+			break
+		}
+	}
+
+	b.zdd.root = b.build()
+	if dl.err != nil {
+		return nil
+	}
+	return b.zdd
+}
+
+// Bookkeeping for building a ZDD.
+type zddBuilder struct {
+	dl  *DLX
+	zdd *ZDD
+
+	// Existing nodes, so that equal nodes are shared.
+	unique map[zddNode]int
+
+	// Nodes representing already solved subproblems, keyed by their
+	// state.
+	memo map[string]int
+
+	// Whether there are secondary items, in which case the remaining
+	// items alone no longer determine the remaining options.
+	secondary bool
+}
+
+func (b *zddBuilder) build() int {
+	dl := b.dl
+	item := dl.nextItem()
+	switch {
+	case item == root:
+		return zddTop
+	case dl.items[item].choices == 0:
+		return zddBottom
+	}
+
+	key := b.key()
+	if node, ok := b.memo[key]; ok {
+		return node
+	}
+
+	options := []int{}
+	head := dl.items[item].head
+	for entry := int(dl.entries[head].down); entry != head; entry = int(dl.entries[entry].down) {
+		options = append(options, int(dl.entries[entry].option))
+	}
+
+	// Chain the options covering the item, so that the first option
+	// ends up on top.
+	node := zddBottom
+	for i := len(options) - 1; i >= 0; i-- {
+		if !dl.visit() {
+			return zddBottom
+		}
+
+		dl.chooseOption(options[i])
+		hi := b.build()
+		dl.unchooseOption(options[i])
+
+		node = b.node(options[i], node, hi)
+	}
+
+	b.memo[key] = node
+	return node
+}
+
+// Returns the (unique) node for the given option and branches.
+func (b *zddBuilder) node(option, lo, hi int) int {
+	// Zero-suppression: a node whose `hi` branch is empty is redundant.
+	if hi == zddBottom {
+		return lo
+	}
+
+	n := zddNode{option, lo, hi}
+	if index, ok := b.unique[n]; ok {
+		return index
+	}
+	b.zdd.nodes = append(b.zdd.nodes, n)
+	b.unique[n] = len(b.zdd.nodes) - 1
+	return len(b.zdd.nodes) - 1
+}
+
+// Encodes the current search state: a bitset of the remaining items,
+// followed by a bitset of the remaining options if need be.
+func (b *zddBuilder) key() string {
+	dl := b.dl
+	size := (len(dl.items) + 7) / 8
+	if b.secondary {
+		size += (len(dl.options) + 7) / 8
+	}
+	bits := make([]byte, size)
+
+	for item := dl.itemHead.right; item != root; item = dl.items[item].right {
+		bits[item/8] |= 1 << (item % 8)
+	}
+
+	if b.secondary {
+		offset := (len(dl.items) + 7) / 8
+		for option := range dl.options {
+			if dl.optionLive(option) {
+				bits[offset+option/8] |= 1 << (option % 8)
+			}
+		}
+	}
+
+	return string(bits)
+}
+
+// Size returns the number of non-terminal nodes in the diagram.
+func (z *ZDD) Size() int {
+	return len(z.nodes) - 2
+}
+
+// Count returns the number of covers represented by the diagram.
+func (z *ZDD) Count() *big.Int {
+	return new(big.Int).Set(z.count(z.root))
+}
+
+func (z *ZDD) count(node int) *big.Int {
+	if z.counts == nil {
+		z.counts = make([]*big.Int, len(z.nodes))
+		z.counts[zddBottom] = big.NewInt(0)
+		z.counts[zddTop] = big.NewInt(1)
+	}
+
+	if z.counts[node] == nil {
+		n := z.nodes[node]
+		z.counts[node] = new(big.Int).Add(z.count(n.lo), z.count(n.hi))
+	}
+	return z.counts[node]
+}
+
+// Contains reports whether the given cover, in any order, is among
+// the covers represented by the diagram.
+func (z *ZDD) Contains(cover []int) bool {
+	matched := 0
+	node := z.root
+	for node != zddBottom && node != zddTop {
+		n := z.nodes[node]
+		if intSliceContains(cover, n.option) {
+			matched++
+			node = n.hi
+		} else {
+			node = n.lo
+		}
+	}
+	return node == zddTop && matched == len(cover)
+}
+
+// Cover returns the k-th cover represented by the diagram, counting
+// from zero.  It panics unless 0 <= k < z.Count().
+func (z *ZDD) Cover(k *big.Int) []int {
+	if count := z.count(z.root); k.Sign() < 0 || k.Cmp(count) >= 0 {
+		panic(fmt.Sprintf("dancinglinks: cover %v out of range [0, %v)", k, count))
+	}
+	k = new(big.Int).Set(k)
+	cover := []int{}
+	node := z.root
+	for node != zddTop {
+		n := z.nodes[node]
+		if hiCount := z.count(n.hi); k.Cmp(hiCount) < 0 {
+			cover = append(cover, n.option)
+			node = n.hi
+		} else {
+			k.Sub(k, hiCount)
+			node = n.lo
+		}
+	}
+	return cover
+}
+
+// Sample returns a uniformly random cover represented by the diagram,
+// or nil if there are none.
+func (z *ZDD) Sample(r *rand.Rand) []int {
+	count := z.count(z.root)
+	if count.Sign() == 0 {
+		return nil
+	}
+	return z.Cover(new(big.Int).Rand(r, count))
+}
+
+// GenerateCovers calls yield with each cover represented by the
+// diagram, stopping early if yield returns false.  It returns false
+// if stopped early.
+func (z *ZDD) GenerateCovers(yield func([]int) bool) bool {
+	cover := []int{}
+	var walk func(node int) bool
+	walk = func(node int) bool {
+		switch node {
+		case zddBottom:
+			return true
+		case zddTop:
+			return yield(append([]int{}, cover...))
+		}
+
+		n := z.nodes[node]
+		cover = append(cover, n.option)
+		if !walk(n.hi) {
+			return false
+		}
+		cover = cover[:len(cover)-1]
+		return walk(n.lo)
+	}
+	return walk(z.root)
+}
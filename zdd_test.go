@@ -0,0 +1,63 @@
+package dancinglinks
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestZDD(t *testing.T) {
+	for _, e := range []example{
+		classic,
+		classicDuplicates,
+		impossible,
+		trivial,
+	} {
+		dl := e.toDLX()
+		zdd := dl.ZDD()
+
+		if count := zdd.Count(); count.Int64() != int64(len(e.solution)) {
+			t.Errorf("should count %d covers, counted %v", len(e.solution), count)
+		}
+
+		covers := [][]int{}
+		zdd.GenerateCovers(func(cover []int) bool {
+			if !zdd.Contains(cover) {
+				t.Errorf("should contain its own cover %v", cover)
+			}
+			covers = append(covers, cover)
+			return true
+		})
+
+		expected := dl.AllCovers()
+		sortSequences(covers)
+		sortSequences(expected)
+		if !reflect.DeepEqual(covers, expected) {
+			t.Errorf("covers mismatch: should be %v, got %v", expected, covers)
+		}
+	}
+
+	zdd := classicDuplicates.toDLX().ZDD()
+	if zdd.Contains([]int{0, 1}) || zdd.Contains([]int{6, 4}) {
+		t.Errorf("should not contain non-covers")
+	}
+	for k := int64(0); k < 4; k++ {
+		if cover := zdd.Cover(big.NewInt(k)); !zdd.Contains(cover) {
+			t.Errorf("cover %d should be contained, got %v", k, cover)
+		}
+	}
+	if cover := zdd.Sample(rand.New(rand.NewSource(1))); !zdd.Contains(cover) {
+		t.Errorf("sampled cover should be contained, got %v", cover)
+	}
+	for _, k := range []int64{-1, 4} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("cover %d should panic", k)
+				}
+			}()
+			zdd.Cover(big.NewInt(k))
+		}()
+	}
+}